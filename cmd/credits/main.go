@@ -0,0 +1,68 @@
+// Command credits checks that every game asset comes with a well-formed credit file,
+// and writes a consolidated attribution report of all assets and libraries.
+//
+// Usage, from the root of the repository:
+//
+//	go run ./cmd/credits -format html -output attributions.html
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/assets/credit"
+)
+
+func main() {
+	assetsDir := flag.String("assets", "src/ms2k/assets/files", "directory containing the assets and their credit files")
+	goModPath := flag.String("gomod", "go.mod", "go.mod file whose direct requirements must all be credited, empty to skip this check")
+	format := flag.String("format", "markdown", "format of the attribution report, either markdown or html")
+	outputPath := flag.String("output", "", "file to write the attribution report to, empty to write it to the standard output")
+	flag.Parse()
+
+	if *format != "markdown" && *format != "html" {
+		log.Fatalf("unsupported format [%s]", *format)
+	}
+
+	report, problems := credit.Check(os.DirFS(*assetsDir))
+	if *goModPath != "" && report != nil {
+		goMod, err := os.ReadFile(*goModPath)
+		if err != nil {
+			log.Fatalf("failed to read go.mod file: %v", err)
+		}
+		problems = append(problems, credit.CheckLibraries(report, goMod)...)
+	}
+
+	for _, problem := range problems {
+		fmt.Fprintln(os.Stderr, problem)
+	}
+	if report == nil {
+		os.Exit(1)
+	}
+
+	output := io.Writer(os.Stdout)
+	if *outputPath != "" {
+		file, err := os.Create(*outputPath)
+		if err != nil {
+			log.Fatalf("failed to create report file: %v", err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	writeReport := report.WriteMarkdown
+	if *format == "html" {
+		writeReport = report.WriteHTML
+	}
+	if err := writeReport(output); err != nil {
+		log.Fatalf("failed to write report: %v", err)
+	}
+
+	if len(problems) != 0 {
+		fmt.Fprintf(os.Stderr, "found %v problem(s)\n", len(problems))
+		os.Exit(1)
+	}
+}
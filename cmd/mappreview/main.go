@@ -0,0 +1,80 @@
+// Command mappreview generates an area of the world of a seed without playing it, the same way the game does.
+// It draws the area as a PNG map, dumps its planets and worm holes as JSON or CSV,
+// and prints statistics such as the density of each chunk and the planets nearest to Earth.
+//
+// Usage, from the root of the repository:
+//
+//	go run ./cmd/mappreview -seed michel -area -4,-4,3,3 -png map.png -dump planets.csv
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image/png"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/generation"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/preview"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/rng"
+)
+
+func main() {
+	seed := flag.String("seed", "", "seed of the world, empty for a random one")
+	areaFlag := flag.String("area", "-3,-3,2,2", "chunks to generate, as minX,minY,maxX,maxY with bounds included; Earth is at the corner of chunk 0,0")
+	pngPath := flag.String("png", "map.png", "file to draw the map to, empty to skip it")
+	pixelsPerChunk := flag.Int("pixels", 128, "number of pixels on each side of a chunk in the map")
+	dumpPath := flag.String("dump", "", "file to dump the planets and worm holes to, as CSV if its extension is .csv and as JSON otherwise, empty to skip it")
+	numberOfNearestPlanets := flag.Int("nearest", 10, "number of planets nearest to Earth to report")
+	flag.Parse()
+
+	area := preview.Area{}
+	if _, err := fmt.Sscanf(*areaFlag, "%d,%d,%d,%d", &area.MinX, &area.MinY, &area.MaxX, &area.MaxY); err != nil {
+		log.Fatalf("invalid area [%s]: %v", *areaFlag, err)
+	}
+	if area.MinX > area.MaxX || area.MinY > area.MaxY {
+		log.Fatalf("invalid area [%s]: minimal coordinates must not be greater than maximal ones", *areaFlag)
+	}
+	if *pixelsPerChunk <= 0 {
+		log.Fatalf("invalid number of pixels per chunk [%d]", *pixelsPerChunk)
+	}
+
+	r, err := rng.NewRNG(*seed)
+	if err != nil {
+		log.Fatalf("failed to initialize rng: %v", err)
+	}
+	m := preview.Generate(r, area, generation.GameParameters)
+
+	if *pngPath != "" {
+		writeFile(*pngPath, func(w io.Writer) error {
+			return png.Encode(w, m.Render(*pixelsPerChunk))
+		})
+	}
+	if *dumpPath != "" {
+		writeFile(*dumpPath, func(w io.Writer) error {
+			if filepath.Ext(*dumpPath) == ".csv" {
+				return m.WriteCSV(w)
+			}
+			return m.WriteJSON(w, *numberOfNearestPlanets)
+		})
+	}
+
+	if err := m.WriteReport(os.Stdout, *numberOfNearestPlanets); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeFile(path string, write func(w io.Writer) error) {
+	file, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("failed to create file [%s]: %v", path, err)
+	}
+	if err := write(file); err != nil {
+		log.Fatalf("failed to write file [%s]: %v", path, err)
+	}
+	if err := file.Close(); err != nil {
+		log.Fatalf("failed to close file [%s]: %v", path, err)
+	}
+}
@@ -0,0 +1,250 @@
+//go:build wasm
+
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall/js"
+)
+
+const (
+	manifestFileName       = "manifest.json"
+	cacheNamePrefix        = "ms2k-assets-"
+	cacheVersionStorageKey = "ms2k-assets-version"
+)
+
+// assetManifest lists the hash of the content of every fetchable asset.
+// It is generated by web/extract_assets.sh.
+type assetManifest struct {
+	Version string            `json:"version"`
+	Files   map[string]string `json:"files"`
+}
+
+// assetCache stores fetched assets in the browser Cache API, keyed by their content hash.
+// A nil *assetCache is valid and caches nothing.
+type assetCache struct {
+	cache    js.Value
+	manifest assetManifest
+}
+
+var (
+	// assetsBaseURL is the URL from which assets are fetched, resolved only once
+	assetsBaseURL = sync.OnceValue(func() string {
+		href := js.Global().Get("location").Get("href").String()
+		href = strings.TrimSuffix(href, "/index.html")
+		return strings.TrimSuffix(href, "/") + "/assets/"
+	})
+
+	openedAssetCache     *assetCache
+	openedAssetCacheLock sync.Mutex
+)
+
+// sharedAssetCache returns the asset cache, opening it on first use. Once opened, it is kept for the rest of the page load,
+// as the manifest cannot change in between. Failures are not kept, so that retrying to load assets also retries to open the cache.
+func sharedAssetCache() *assetCache {
+	openedAssetCacheLock.Lock()
+	defer openedAssetCacheLock.Unlock()
+	if openedAssetCache == nil {
+		cache, err := openAssetCache()
+		if err != nil {
+			fmt.Println("assets will not be cached: " + err.Error())
+			return nil
+		}
+		openedAssetCache = cache
+	}
+	return openedAssetCache
+}
+
+// openAssetCache fetches the asset manifest and opens the matching cache, deleting caches of previous versions.
+// If the manifest cannot be fetched, for instance when offline, the cache of the last known version is used.
+func openAssetCache() (*assetCache, error) {
+	caches := js.Global().Get("caches")
+	if !caches.Truthy() {
+		return nil, errors.New("the Cache API is not available")
+	}
+	localStorage := js.Global().Get("localStorage")
+
+	manifest, err := fetchManifest()
+	if err != nil {
+		if !localStorage.Truthy() {
+			return nil, err
+		}
+		lastVersion := localStorage.Call("getItem", cacheVersionStorageKey)
+		if lastVersion.Type() != js.TypeString {
+			return nil, err
+		}
+		rawManifest, cacheErr := awaitPromise(caches.Call("match", manifestCacheKey(lastVersion.String())))
+		if cacheErr != nil || !rawManifest.Truthy() {
+			return nil, err
+		}
+		manifestData, cacheErr := responseBytes(rawManifest)
+		if cacheErr != nil {
+			return nil, err
+		}
+		manifest = &assetManifest{}
+		if cacheErr := json.Unmarshal(manifestData, manifest); cacheErr != nil {
+			return nil, err
+		}
+	}
+
+	cacheName := cacheNamePrefix + manifest.Version
+	cache, err := awaitPromise(caches.Call("open", cacheName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache [%s]: %w", cacheName, err)
+	}
+
+	if rawManifest, err := json.Marshal(manifest); err == nil {
+		putInCache(cache, manifestCacheKey(manifest.Version), rawManifest)
+	}
+	if localStorage.Truthy() {
+		localStorage.Call("setItem", cacheVersionStorageKey, manifest.Version)
+	}
+
+	if cacheNames, err := awaitPromise(caches.Call("keys")); err == nil {
+		for i := 0; i < cacheNames.Length(); i++ {
+			name := cacheNames.Index(i).String()
+			if strings.HasPrefix(name, cacheNamePrefix) && name != cacheName {
+				caches.Call("delete", name)
+			}
+		}
+	}
+
+	return &assetCache{
+		cache:    cache,
+		manifest: *manifest,
+	}, nil
+}
+
+func fetchManifest() (*assetManifest, error) {
+	request, err := http.NewRequest(http.MethodGet, assetsBaseURL()+manifestFileName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manifest request: %w", err)
+	}
+	request.Header.Set("Cache-Control", "no-cache")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %v when fetching manifest", response.StatusCode)
+	}
+
+	manifest := &assetManifest{}
+	if err := json.NewDecoder(response.Body).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if manifest.Version == "" {
+		return nil, errors.New("manifest has no version")
+	}
+	return manifest, nil
+}
+
+// key returns the cache key of the asset at the given path, or false if the asset is not listed in the manifest
+func (ac *assetCache) key(path string) (string, bool) {
+	if ac == nil {
+		return "", false
+	}
+	hash, ok := ac.manifest.Files[path]
+	if !ok {
+		return "", false
+	}
+	return assetsBaseURL() + path + "?v=" + hash, true
+}
+
+func manifestCacheKey(version string) string {
+	return assetsBaseURL() + manifestFileName + "?v=" + version
+}
+
+// load returns the cached content of the asset at the given path, if any
+func (ac *assetCache) load(path string) ([]byte, bool) {
+	key, ok := ac.key(path)
+	if !ok {
+		return nil, false
+	}
+	response, err := awaitPromise(ac.cache.Call("match", key))
+	if err != nil || !response.Truthy() {
+		return nil, false
+	}
+	content, err := responseBytes(response)
+	if err != nil {
+		return nil, false
+	}
+	return content, true
+}
+
+// store caches the content of the asset at the given path, provided it matches the hash from the manifest
+func (ac *assetCache) store(path string, content []byte) {
+	key, ok := ac.key(path)
+	if !ok {
+		return
+	}
+	hash := sha256.Sum256(content)
+	if hex.EncodeToString(hash[:]) != ac.manifest.Files[path] {
+		fmt.Println("not caching asset [" + path + "] as its content does not match the manifest")
+		return
+	}
+	putInCache(ac.cache, key, content)
+}
+
+func putInCache(cache js.Value, key string, content []byte) {
+	array := js.Global().Get("Uint8Array").New(len(content))
+	js.CopyBytesToJS(array, content)
+	response := js.Global().Get("Response").New(array)
+	if _, err := awaitPromise(cache.Call("put", key, response)); err != nil {
+		fmt.Println("failed to cache [" + key + "]: " + err.Error())
+	}
+}
+
+func responseBytes(response js.Value) ([]byte, error) {
+	arrayBuffer, err := awaitPromise(response.Call("arrayBuffer"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached response: %w", err)
+	}
+	array := js.Global().Get("Uint8Array").New(arrayBuffer)
+	content := make([]byte, array.Length())
+	js.CopyBytesToGo(content, array)
+	return content, nil
+}
+
+// awaitPromise blocks until the given JavaScript promise settles.
+// It must not be called from a JavaScript callback.
+func awaitPromise(promise js.Value) (js.Value, error) {
+	resultChan, errChan := make(chan js.Value, 1), make(chan error, 1)
+
+	onFulfilled := js.FuncOf(func(_ js.Value, args []js.Value) any {
+		result := js.Undefined()
+		if len(args) > 0 {
+			result = args[0]
+		}
+		resultChan <- result
+		return nil
+	})
+	defer onFulfilled.Release()
+	onRejected := js.FuncOf(func(_ js.Value, args []js.Value) any {
+		err := error(errors.New("promise rejected"))
+		if len(args) > 0 {
+			err = js.Error{Value: args[0]}
+		}
+		errChan <- err
+		return nil
+	})
+	defer onRejected.Release()
+
+	promise.Call("then", onFulfilled, onRejected)
+
+	select {
+	case result := <-resultChan:
+		return result, nil
+	case err := <-errChan:
+		return js.Value{}, err
+	}
+}
@@ -4,11 +4,8 @@ import (
 	"bytes"
 	"context"
 	"embed"
-	"encoding/json"
 	"fmt"
 	"image"
-	"path/filepath"
-	"strings"
 
 	_ "image/png" // needed to correctly load PNG files
 
@@ -18,6 +15,7 @@ import (
 	"golang.org/x/image/font/opentype"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/RemiEven/michelSpace2000/src/ms2k/assets/credit"
 	"github.com/RemiEven/michelSpace2000/src/ms2k/assets/internal/genericsync"
 )
 
@@ -33,13 +31,37 @@ type Library struct {
 	WavSounds     genericsync.Map[string, []byte]
 	SoundsCredits genericsync.Map[string, Credit]
 
-	FontFaces        genericsync.Map[string, font.Face]
+	Fonts            genericsync.Map[string, *opentype.Font]
 	FontFacesCredits genericsync.Map[string, Credit]
+	fontFaces        genericsync.Map[fontFaceKey, font.Face]
+
+	LibrariesCredits genericsync.Map[string, Credit]
+
+	files    *moddedFS
+	progress *Progress
 }
 
-// NewAssetLibrary creates a new asset library with all assets loaded
-func NewAssetLibrary() (<-chan *Library, <-chan error) {
+// NewAssetLibrary creates a new asset library with all assets loaded.
+// The returned progress can be polled while assets are loading.
+func NewAssetLibrary() (<-chan *Library, <-chan error, *Progress) {
 	libraryChan, errChan := make(chan *Library), make(chan error)
+	progress := &Progress{}
+
+	mods, err := loadMods()
+	if err != nil {
+		go func() {
+			errChan <- fmt.Errorf("failed to load mods: %w", err)
+		}()
+		return libraryChan, errChan, progress
+	}
+	files, err := newModdedFS(mods)
+	if err != nil {
+		go func() {
+			errChan <- err
+		}()
+		return libraryChan, errChan, progress
+	}
+
 	eg, ctx := errgroup.WithContext(context.Background())
 	eg.SetLimit(7)
 
@@ -51,10 +73,17 @@ func NewAssetLibrary() (<-chan *Library, <-chan error) {
 		WavSounds:     genericsync.Map[string, []byte]{},
 		SoundsCredits: genericsync.Map[string, Credit]{},
 
-		FontFaces:        genericsync.Map[string, font.Face]{},
+		Fonts:            genericsync.Map[string, *opentype.Font]{},
 		FontFacesCredits: genericsync.Map[string, Credit]{},
+		fontFaces:        genericsync.Map[fontFaceKey, font.Face]{},
+
+		LibrariesCredits: genericsync.Map[string, Credit]{},
+
+		files:    files,
+		progress: progress,
 	}
 
+	loaders := []func(context.Context) error{}
 	for name, path := range map[string]string{
 		"ships":      "modular_ships.png",
 		"planet":     "Green Gas Planet.png",
@@ -66,27 +95,43 @@ func NewAssetLibrary() (<-chan *Library, <-chan error) {
 		"ui/listbox": "ui/listbox_default.png",
 	} {
 		path, name := path, name
-		eg.Go(func() error {
+		loaders = append(loaders, func(ctx context.Context) error {
 			return al.loadImage(ctx, path, name)
 		})
 	}
 
-	eg.Go(func() error {
-		return al.loadMP3Sound(ctx, "Hardmoon_-_Deep_space.mp3", "music")
-	})
-
-	eg.Go(func() error {
-		return al.loadWavSound(ctx, "click.wav", "click")
-	})
-	eg.Go(func() error {
-		return al.loadWavSound(ctx, "click_2.wav", "click_2")
-	})
-
-	eg.Go(func() error {
-		return al.loadFontFace(ctx, "Oxanium-Regular.ttf", "oxanium")
-	})
+	loaders = append(loaders,
+		func(ctx context.Context) error {
+			return al.loadMP3Sound(ctx, "Hardmoon_-_Deep_space.mp3", "music")
+		},
+		func(ctx context.Context) error {
+			return al.loadWavSound(ctx, "click.wav", "click")
+		},
+		func(ctx context.Context) error {
+			return al.loadWavSound(ctx, "click_2.wav", "click_2")
+		},
+		func(ctx context.Context) error {
+			return al.loadFont(ctx, "Oxanium-Regular.ttf", "oxanium")
+		},
+		func(context.Context) error {
+			return al.loadLibrariesCredits()
+		},
+	)
+
+	progress.totalAssets.Store(int64(len(loaders)))
 
 	go func() {
+		for _, loader := range loaders {
+			loader := loader
+			eg.Go(func() error {
+				if err := loader(ctx); err != nil {
+					return err
+				}
+				progress.loadedAssets.Add(1)
+				return nil
+			})
+		}
+
 		if err := eg.Wait(); err != nil {
 			errChan <- fmt.Errorf("failed to load an asset: %w", err)
 			return
@@ -98,7 +143,7 @@ func NewAssetLibrary() (<-chan *Library, <-chan error) {
 		libraryChan <- al
 	}()
 
-	return libraryChan, errChan
+	return libraryChan, errChan, progress
 }
 
 func (al *Library) loadImage(ctx context.Context, path, name string) error {
@@ -114,7 +159,7 @@ func (al *Library) loadImage(ctx context.Context, path, name string) error {
 	}
 	al.Images.Store(name, ebiten.NewImageFromImage(img))
 
-	credit, err := loadCredits(absolutePath)
+	credit, err := al.loadCredits(absolutePath)
 	if err != nil {
 		return fmt.Errorf("failed to load credit file for [%q]: %w", name, err)
 	}
@@ -131,7 +176,7 @@ func (al *Library) loadMP3Sound(ctx context.Context, path, name string) error {
 	}
 	al.MP3Sounds.Store(name, sound)
 
-	credit, err := loadCredits(absolutePath)
+	credit, err := al.loadCredits(absolutePath)
 	if err != nil {
 		return fmt.Errorf("failed to load credit file for [%q]: %w", name, err)
 	}
@@ -148,7 +193,7 @@ func (al *Library) loadWavSound(ctx context.Context, path, name string) error {
 	}
 	al.WavSounds.Store(name, sound)
 
-	credit, err := loadCredits(absolutePath)
+	credit, err := al.loadCredits(absolutePath)
 	if err != nil {
 		return fmt.Errorf("failed to load credit file for [%q]: %w", name, err)
 	}
@@ -157,7 +202,7 @@ func (al *Library) loadWavSound(ctx context.Context, path, name string) error {
 	return nil
 }
 
-func (al *Library) loadFontFace(ctx context.Context, path, name string) error {
+func (al *Library) loadFont(ctx context.Context, path, name string) error {
 	absolutePath := "font/" + path
 
 	fontFileData, err := al.getFileData(ctx, absolutePath)
@@ -169,37 +214,75 @@ func (al *Library) loadFontFace(ctx context.Context, path, name string) error {
 		return fmt.Errorf("failed to parse font [%q]: %w", name, err)
 	}
 
+	al.Fonts.Store(name, parsedFont)
+
+	credit, err := al.loadCredits(absolutePath)
+	if err != nil {
+		return fmt.Errorf("failed to load credit file for [%q]: %w", name, err)
+	}
+	al.FontFacesCredits.Store(name, *credit)
+
+	return nil
+}
+
+// fontFaceKey identifies a font face by the name of its font and its size
+type fontFaceKey struct {
+	name string
+	size float64
+}
+
+// FontFace returns a face of the named font at the given size, creating it on first request.
+// Fonts come in a single weight, so other weights are available as differently named fonts.
+func (al *Library) FontFace(name string, size float64) (font.Face, error) {
+	key := fontFaceKey{name: name, size: size}
+	if fontFace, ok := al.fontFaces.Load(key); ok {
+		return fontFace, nil
+	}
+
+	parsedFont, ok := al.Fonts.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown font [%q]", name)
+	}
+
 	const dpi = 72
 	fontFace, err := opentype.NewFace(parsedFont, &opentype.FaceOptions{
-		Size:    24,
+		Size:    size,
 		DPI:     dpi,
 		Hinting: font.HintingFull,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create face from parsed font [%q]: %w", name, err)
+		return nil, fmt.Errorf("failed to create face of size %v from parsed font [%q]: %w", size, name, err)
 	}
+	al.fontFaces.Store(key, fontFace)
 
-	al.FontFaces.Store(name, fontFace)
+	return fontFace, nil
+}
 
-	credit, err := loadCredits(absolutePath)
+func (al *Library) loadCredits(absolutePath string) (*Credit, error) {
+	rawCredits, modName, err := al.files.ReadFile(credit.PathFor(absolutePath))
 	if err != nil {
-		return fmt.Errorf("failed to load credit file for [%q]: %w", name, err)
+		return nil, fmt.Errorf("failed to read: %w", err)
 	}
-	al.FontFacesCredits.Store(name, *credit)
+	parsedCredit, err := credit.Parse(rawCredits)
+	if err != nil {
+		return nil, err
+	}
+	parsedCredit.Mod = modName
 
-	return nil
+	return parsedCredit, nil
 }
 
-func loadCredits(absolutePath string) (*Credit, error) {
-	absoluteCreditPath := strings.TrimSuffix(absolutePath, filepath.Ext(absolutePath)) + ".credit.json"
-	rawCredits, err := assetFS.ReadFile("files/" + absoluteCreditPath)
+func (al *Library) loadLibrariesCredits() error {
+	rawLibraries, err := assetFS.ReadFile("files/" + credit.LibrariesFileName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read: %w", err)
+		return fmt.Errorf("failed to read libraries credits: %w", err)
 	}
-	credit := Credit{}
-	if err := json.Unmarshal(rawCredits, &credit); err != nil {
-		return nil, fmt.Errorf("failed to parse: %w", err)
+	libraries, err := credit.ParseLibraries(rawLibraries)
+	if err != nil {
+		return fmt.Errorf("failed to load libraries credits: %w", err)
 	}
-
-	return &credit, nil
+	for _, library := range libraries {
+		al.LibrariesCredits.Store(library.Name, library.Credit)
+	}
+	return nil
 }
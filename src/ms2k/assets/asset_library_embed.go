@@ -4,13 +4,148 @@ package assets
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/assets/credit"
+)
+
+const (
+	modsDirEnvVar   = "MS2K_MODS_DIR"
+	modListFileName = "mods.json"
 )
 
 func (al *Library) getFileData(_ context.Context, path string) ([]byte, error) {
-	fileData, err := assetFS.ReadFile("files/" + path)
+	fileData, _, err := al.files.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read path [%s]: %w", path, err)
+		return nil, err
 	}
+	al.progress.expectedBytes.Add(int64(len(fileData)))
+	al.progress.loadedBytes.Add(int64(len(fileData)))
 	return fileData, nil
 }
+
+// modsDir returns the directory in which the player can install mods
+func modsDir() (string, error) {
+	if dir := os.Getenv(modsDirEnvVar); dir != "" {
+		return dir, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "MichelSpace2000", "mods"), nil
+}
+
+// loadMods loads and validates the mods enabled in the mod list of the mods directory.
+// Having no mods directory or no mod list is not an error and simply means no mod is enabled.
+func loadMods() ([]mod, error) {
+	dir, err := modsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	modListPath := filepath.Join(dir, modListFileName)
+	rawModList, err := os.ReadFile(modListPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read mod list [%s]: %w", modListPath, err)
+	}
+
+	modNames := []string{}
+	if err := json.Unmarshal(rawModList, &modNames); err != nil {
+		return nil, fmt.Errorf("failed to parse mod list [%s], it should be a JSON array of mod directory names: %w", modListPath, err)
+	}
+
+	base, err := fs.Sub(assetFS, "files")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded assets: %w", err)
+	}
+
+	mods := make([]mod, 0, len(modNames))
+	seenModNames := map[string]struct{}{}
+	errs := []error{}
+	for i, name := range modNames {
+		switch {
+		case name == "":
+			errs = append(errs, fmt.Errorf("mod at index %v has an empty name", i))
+			continue
+		case name != filepath.Base(name) || name == "." || name == "..":
+			errs = append(errs, fmt.Errorf("mod [%s] must be the name of a directory directly inside [%s]", name, dir))
+			continue
+		}
+		if _, ok := seenModNames[name]; ok {
+			errs = append(errs, fmt.Errorf("mod [%s] is listed more than once", name))
+			continue
+		}
+		seenModNames[name] = struct{}{}
+
+		modDir := filepath.Join(dir, name)
+		if info, err := os.Stat(modDir); err != nil {
+			errs = append(errs, fmt.Errorf("mod [%s] cannot be opened: %w", name, err))
+			continue
+		} else if !info.IsDir() {
+			errs = append(errs, fmt.Errorf("mod [%s] is not a directory", name))
+			continue
+		}
+
+		m := mod{
+			name: name,
+			fsys: os.DirFS(modDir),
+		}
+		if err := validateMod(m, base); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		mods = append(mods, m)
+	}
+
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("invalid mod list [%s]: %w", modListPath, errors.Join(errs...))
+	}
+	return mods, nil
+}
+
+// validateMod checks that all files of a mod override an existing asset, and that overridden assets come with a valid credit file
+func validateMod(m mod, base fs.FS) error {
+	errs := []error{}
+	err := fs.WalkDir(m.fsys, ".", func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if _, err := fs.Stat(base, filePath); err != nil {
+			errs = append(errs, fmt.Errorf("mod [%s]: file [%s] does not override any game asset", m.name, filePath))
+			return nil
+		}
+		if filePath == credit.LibrariesFileName {
+			errs = append(errs, fmt.Errorf("mod [%s]: credits of programming libraries cannot be overridden", m.name))
+			return nil
+		}
+		if strings.HasSuffix(filePath, credit.FileSuffix) {
+			return nil
+		}
+		creditPath := credit.PathFor(filePath)
+		rawCredit, err := fs.ReadFile(m.fsys, creditPath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("mod [%s]: overridden asset [%s] has no credit file [%s]", m.name, filePath, path.Base(creditPath)))
+		} else if _, err := credit.Parse(rawCredit); err != nil {
+			errs = append(errs, fmt.Errorf("mod [%s]: invalid credit file [%s]: %w", m.name, creditPath, err))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("mod [%s]: failed to list files: %w", m.name, err)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,101 @@
+//go:build !wasm
+
+package assets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const validModCredit = `{"authors": ["someone"], "source": "https://example.com", "license": "CC0"}`
+
+func TestValidateMod(t *testing.T) {
+	base := fstest.MapFS{
+		"img/ship.png":         {Data: []byte{}},
+		"img/ship.credit.json": {Data: []byte(validModCredit)},
+	}
+	for _, testCase := range []struct {
+		name          string
+		files         fstest.MapFS
+		expectedError string // empty when the mod is valid
+	}{
+		{
+			name: "valid",
+			files: fstest.MapFS{
+				"img/ship.png":         {Data: []byte{}},
+				"img/ship.credit.json": {Data: []byte(validModCredit)},
+			},
+		},
+		{
+			name: "missing credit file",
+			files: fstest.MapFS{
+				"img/ship.png": {Data: []byte{}},
+			},
+			expectedError: "mod [test]: overridden asset [img/ship.png] has no credit file [ship.credit.json]",
+		},
+		{
+			name: "missing license",
+			files: fstest.MapFS{
+				"img/ship.png":         {Data: []byte{}},
+				"img/ship.credit.json": {Data: []byte(`{"authors": ["someone"], "source": "https://example.com"}`)},
+			},
+			expectedError: "mod [test]: invalid credit file [img/ship.credit.json]: no license",
+		},
+		{
+			name: "malformed credit file",
+			files: fstest.MapFS{
+				"img/ship.png":         {Data: []byte{}},
+				"img/ship.credit.json": {Data: []byte(`{"authors": "someone"`)},
+			},
+			expectedError: "mod [test]: invalid credit file [img/ship.credit.json]: failed to parse",
+		},
+		{
+			name: "unknown asset",
+			files: fstest.MapFS{
+				"img/boat.png": {Data: []byte{}},
+			},
+			expectedError: "mod [test]: file [img/boat.png] does not override any game asset",
+		},
+	} {
+		err := validateMod(mod{name: "test", fsys: testCase.files}, base)
+		switch {
+		case testCase.expectedError == "" && err != nil:
+			t.Errorf("mod [%s] should be valid: got [%v]", testCase.name, err)
+		case testCase.expectedError != "" && (err == nil || !strings.HasPrefix(err.Error(), testCase.expectedError)):
+			t.Errorf("unexpected error for mod [%s]: wanted [%s], got [%v]", testCase.name, testCase.expectedError, err)
+		}
+	}
+}
+
+func TestLoadModsRejectsInvalidModLists(t *testing.T) {
+	for _, testCase := range []struct {
+		name          string
+		modList       string
+		expectedError string
+	}{
+		{"malformed", `["my-ships"`, "failed to parse mod list"},
+		{"not an array", `{"mods": ["my-ships"]}`, "failed to parse mod list"},
+		{"empty name", `[""]`, "mod at index 0 has an empty name"},
+		{"nested directory", `["my/ships"]`, "mod [my/ships] must be the name of a directory"},
+		{"listed twice", `["my-ships", "my-ships"]`, "mod [my-ships] is listed more than once"},
+		{"missing directory", `["missing"]`, "mod [missing] cannot be opened"},
+	} {
+		dir := t.TempDir()
+		t.Setenv(modsDirEnvVar, dir)
+		if err := os.Mkdir(filepath.Join(dir, "my-ships"), 0o755); err != nil {
+			t.Errorf("failed to create mod directory: %v", err)
+			return
+		}
+		if err := os.WriteFile(filepath.Join(dir, modListFileName), []byte(testCase.modList), 0o644); err != nil {
+			t.Errorf("failed to write mod list: %v", err)
+			return
+		}
+
+		if _, err := loadMods(); err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+			t.Errorf("unexpected error for mod list [%s]: wanted [%s], got [%v]", testCase.name, testCase.expectedError, err)
+		}
+	}
+}
@@ -4,20 +4,53 @@ package assets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
-	"syscall/js"
+	"time"
 )
 
+const (
+	maxFetchAttempts      = 5
+	initialFetchRetryWait = 500 * time.Millisecond
+)
+
+// errNotRetryable wraps errors for which fetching the same file again would not help
+var errNotRetryable = errors.New("not retryable")
+
 func (al *Library) getFileData(ctx context.Context, path string) ([]byte, error) {
-	href := js.Global().Get("location").Get("href").String()
-	href = strings.TrimSuffix(href, "/index.html") // TODO: avoid doing this for each file
+	cache := sharedAssetCache()
+	if fileData, ok := cache.load(path); ok {
+		al.progress.expectedBytes.Add(int64(len(fileData)))
+		al.progress.loadedBytes.Add(int64(len(fileData)))
+		return fileData, nil
+	}
+
+	retryWait := initialFetchRetryWait
+	for attempt := 1; ; attempt++ {
+		fileData, err := al.fetchFileData(ctx, path)
+		if err == nil {
+			cache.store(path, fileData)
+			return fileData, nil
+		}
+		if attempt == maxFetchAttempts || errors.Is(err, errNotRetryable) {
+			return nil, fmt.Errorf("gave up after %v attempt(s): %w", attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped retrying: %w", ctx.Err())
+		case <-time.After(retryWait):
+		}
+		retryWait *= 2
+	}
+}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, href+"/assets/"+path, nil)
+func (al *Library) fetchFileData(ctx context.Context, path string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, assetsBaseURL()+path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request for path [%s]: %w", path, err)
+		return nil, fmt.Errorf("failed to create request for path [%s]: %w (%w)", path, err, errNotRetryable)
 	}
 
 	response, err := http.DefaultClient.Do(request)
@@ -26,10 +59,35 @@ func (al *Library) getFileData(ctx context.Context, path string) ([]byte, error)
 	}
 	defer response.Body.Close()
 
-	fileData, err := io.ReadAll(response.Body)
+	if response.StatusCode != http.StatusOK {
+		err := fmt.Errorf("unexpected status code %v when fetching file for path [%s]", response.StatusCode, path)
+		if response.StatusCode < 500 && response.StatusCode != http.StatusRequestTimeout && response.StatusCode != http.StatusTooManyRequests {
+			err = fmt.Errorf("%w (%w)", err, errNotRetryable)
+		}
+		return nil, err
+	}
+
+	expectedBytes := max(response.ContentLength, 0)
+	al.progress.expectedBytes.Add(expectedBytes)
+	body := &countingReader{
+		reader:  response.Body,
+		counter: &al.progress.loadedBytes,
+	}
+
+	fileData, err := io.ReadAll(body)
 	if err != nil {
+		al.progress.expectedBytes.Add(-expectedBytes)
+		al.progress.loadedBytes.Add(-body.count)
 		return nil, fmt.Errorf("failed to read fetched data for path [%s]: %w", path, err)
 	}
+	if expectedBytes == 0 {
+		al.progress.expectedBytes.Add(body.count)
+	}
 
 	return fileData, nil
 }
+
+// loadMods returns no mod, as they are only supported on desktop
+func loadMods() ([]mod, error) {
+	return nil, nil
+}
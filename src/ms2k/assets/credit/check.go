@@ -0,0 +1,122 @@
+package credit
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Asset pairs the path of an asset with its credit
+type Asset struct {
+	Path   string
+	Credit Credit
+}
+
+// Category returns the kind of asset, deduced from the top-level directory it is in
+func (asset *Asset) Category() string {
+	directory, _, _ := strings.Cut(asset.Path, "/")
+	switch directory {
+	case "img":
+		return "Images"
+	case "font":
+		return "Fonts"
+	case "audio":
+		return "Music and sounds"
+	default:
+		return directory
+	}
+}
+
+// Report holds the credits of all assets and libraries used by the game
+type Report struct {
+	Assets    []Asset
+	Libraries []Library
+}
+
+// Check reads all assets of the given file system along with their credit files.
+// It returns the credits of the assets that are well credited, and a problem for each missing,
+// malformed or orphaned credit file.
+func Check(fsys fs.FS) (*Report, []error) {
+	report := &Report{}
+	problems := []error{}
+
+	assetPaths, creditPaths := map[string]struct{}{}, []string{}
+	err := fs.WalkDir(fsys, ".", func(filePath string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case d.IsDir() || filePath == LibrariesFileName:
+			return nil
+		case strings.HasSuffix(filePath, FileSuffix):
+			creditPaths = append(creditPaths, filePath)
+		case path.Ext(filePath) == ".json":
+			problems = append(problems, fmt.Errorf("[%s]: unexpected JSON file, credit files must end with %s", filePath, FileSuffix))
+		default:
+			assetPaths[filePath] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, append(problems, fmt.Errorf("failed to list assets: %w", err))
+	}
+
+	creditedAssetPaths := map[string]struct{}{}
+	for assetPath := range assetPaths {
+		creditedAssetPaths[PathFor(assetPath)] = struct{}{}
+
+		rawCredit, err := fs.ReadFile(fsys, PathFor(assetPath))
+		if err != nil {
+			problems = append(problems, fmt.Errorf("[%s]: failed to read credit file: %w", assetPath, err))
+			continue
+		}
+		credit, err := Parse(rawCredit)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("[%s]: invalid credit file: %w", assetPath, err))
+			continue
+		}
+		report.Assets = append(report.Assets, Asset{
+			Path:   assetPath,
+			Credit: *credit,
+		})
+	}
+	slices.SortFunc(report.Assets, func(a, b Asset) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	for _, creditPath := range creditPaths {
+		if _, ok := creditedAssetPaths[creditPath]; !ok {
+			problems = append(problems, fmt.Errorf("[%s]: orphaned credit file, no asset matches it", creditPath))
+		}
+	}
+
+	rawLibraries, err := fs.ReadFile(fsys, LibrariesFileName)
+	if err != nil {
+		problems = append(problems, fmt.Errorf("[%s]: failed to read: %w", LibrariesFileName, err))
+	} else if report.Libraries, err = ParseLibraries(rawLibraries); err != nil {
+		problems = append(problems, fmt.Errorf("[%s]: %w", LibrariesFileName, err))
+	}
+
+	slices.SortFunc(problems, func(a, b error) int {
+		return strings.Compare(a.Error(), b.Error())
+	})
+	return report, problems
+}
+
+// CheckLibraries returns a problem for each module directly required by the given go.mod file content
+// that is not credited in the report
+func CheckLibraries(report *Report, goMod []byte) []error {
+	creditedModules := map[string]struct{}{}
+	for _, library := range report.Libraries {
+		creditedModules[library.Module] = struct{}{}
+	}
+
+	problems := []error{}
+	for _, module := range DirectRequirements(goMod) {
+		if _, ok := creditedModules[module]; !ok {
+			problems = append(problems, fmt.Errorf("[%s]: module [%s] is required but not credited", LibrariesFileName, module))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,87 @@
+package credit
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const validCredit = `{"authors": ["someone"], "source": "https://example.com", "license": "Public Domain"}`
+
+func TestCheck(t *testing.T) {
+	fsys := fstest.MapFS{
+		"img/ship.png":              {Data: []byte{}},
+		"img/ship.credit.json":      {Data: []byte(validCredit)},
+		"img/planet.png":            {Data: []byte{}},
+		"img/moon.png":              {Data: []byte{}},
+		"img/moon.credit.json":      {Data: []byte(`{"authors": [], "source": "https://example.com"}`)},
+		"audio/ghost.credit.json":   {Data: []byte(validCredit)},
+		"font/font.ttf":             {Data: []byte{}},
+		"font/font.credit.json":     {Data: []byte(validCredit)},
+		LibrariesFileName:           {Data: []byte(`[{"name": "lib", "module": "example.com/lib", "authors": ["someone"], "source": "https://example.com", "license": "MIT"}]`)},
+		"img/unrelated.config.json": {Data: []byte(`{}`)},
+	}
+
+	report, problems := Check(fsys)
+
+	if len(report.Assets) != 2 {
+		t.Errorf("unexpected number of credited assets: wanted [%v], got [%v]", 2, len(report.Assets))
+		return
+	} else if report.Assets[0].Path != "font/font.ttf" || report.Assets[1].Path != "img/ship.png" {
+		t.Errorf("unexpected credited assets: got [%s] and [%s]", report.Assets[0].Path, report.Assets[1].Path)
+		return
+	}
+	if len(report.Libraries) != 1 {
+		t.Errorf("unexpected number of credited libraries: wanted [%v], got [%v]", 1, len(report.Libraries))
+		return
+	}
+
+	for _, expectedProblem := range []string{
+		"[audio/ghost.credit.json]: orphaned credit file",
+		"[img/moon.png]: invalid credit file: no author\nno license",
+		"[img/planet.png]: failed to read credit file",
+		"[img/unrelated.config.json]: unexpected JSON file",
+	} {
+		found := false
+		for _, problem := range problems {
+			found = found || strings.HasPrefix(problem.Error(), expectedProblem)
+		}
+		if !found {
+			t.Errorf("expected a problem starting with [%s], got %v", expectedProblem, problems)
+			return
+		}
+	}
+	if len(problems) != 4 {
+		t.Errorf("unexpected number of problems: wanted [%v], got %v", 4, problems)
+	}
+}
+
+func TestCheckLibraries(t *testing.T) {
+	report := &Report{
+		Libraries: []Library{{Name: "lib", Module: "example.com/lib"}},
+	}
+	goMod := []byte(`module example.com/game
+
+go 1.21
+
+require example.com/single v1.0.0
+
+require (
+	example.com/lib v1.2.3
+	example.com/other v0.1.0
+)
+
+require (
+	example.com/transitive v1.0.0 // indirect
+)
+`)
+
+	problems := CheckLibraries(report, goMod)
+	if len(problems) != 2 {
+		t.Errorf("unexpected number of problems: wanted [%v], got %v", 2, problems)
+		return
+	}
+	if !strings.Contains(problems[0].Error(), "example.com/single") || !strings.Contains(problems[1].Error(), "example.com/other") {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+}
@@ -0,0 +1,62 @@
+// Package credit describes who created the assets and libraries used by the game, under which license,
+// and checks that all of them are properly credited.
+package credit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FileSuffix is the suffix of credit files, which sit next to the asset they credit
+const FileSuffix = ".credit.json"
+
+// Credit holds information about where an asset was found, who created it and how it is licensed
+type Credit struct {
+	Authors []string `json:"authors"`
+	Source  string   `json:"source"`
+	License string   `json:"license"`
+
+	Mod string `json:"-"` // name of the mod the credited asset comes from, if any
+}
+
+// Parse parses the content of a credit file and checks that it is well-formed
+func Parse(rawCredit []byte) (*Credit, error) {
+	credit := Credit{}
+	if err := json.Unmarshal(rawCredit, &credit); err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+	if err := credit.Validate(); err != nil {
+		return nil, err
+	}
+	return &credit, nil
+}
+
+// Validate returns an error if the credit lacks authors, a source or a license
+func (credit *Credit) Validate() error {
+	errs := []error{}
+	if len(credit.Authors) == 0 {
+		errs = append(errs, errors.New("no author"))
+	}
+	for i, author := range credit.Authors {
+		if strings.TrimSpace(author) == "" {
+			errs = append(errs, fmt.Errorf("author at index %v is empty", i))
+		}
+	}
+	if strings.TrimSpace(credit.Source) == "" {
+		errs = append(errs, errors.New("no source"))
+	}
+	if strings.TrimSpace(credit.License) == "" {
+		errs = append(errs, errors.New("no license"))
+	}
+	return errors.Join(errs...)
+}
+
+// PathFor returns the path of the credit file of the asset at the given path
+func PathFor(assetPath string) string {
+	if i := strings.LastIndexByte(assetPath, '.'); i > strings.LastIndexByte(assetPath, '/') {
+		assetPath = assetPath[:i]
+	}
+	return assetPath + FileSuffix
+}
@@ -0,0 +1,62 @@
+package credit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// LibrariesFileName is the name of the file listing the credits of the Go libraries used by the game
+const LibrariesFileName = "libraries.json"
+
+// Library holds the credit of a Go library used by the game
+type Library struct {
+	Name   string `json:"name"`
+	Module string `json:"module"`
+	Credit
+}
+
+// ParseLibraries parses the content of the libraries file and checks that every library is well credited
+func ParseLibraries(rawLibraries []byte) ([]Library, error) {
+	libraries := []Library{}
+	if err := json.Unmarshal(rawLibraries, &libraries); err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+	for i := range libraries {
+		if libraries[i].Name == "" || libraries[i].Module == "" {
+			return nil, fmt.Errorf("library at index %v must have a name and a module", i)
+		}
+		if err := libraries[i].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid credit for library [%s]: %w", libraries[i].Name, err)
+		}
+	}
+	return libraries, nil
+}
+
+// DirectRequirements lists the modules directly required by the given go.mod file content
+func DirectRequirements(goMod []byte) []string {
+	modules := []string{}
+	inRequireBlock := false
+	scanner := bufio.NewScanner(bytes.NewReader(goMod))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		requirement := ""
+		switch {
+		case line == "require (":
+			inRequireBlock = true
+		case inRequireBlock && line == ")":
+			inRequireBlock = false
+		case inRequireBlock:
+			requirement = line
+		case strings.HasPrefix(line, "require "):
+			requirement = strings.TrimPrefix(line, "require ")
+		}
+		if requirement == "" || strings.HasPrefix(requirement, "//") || strings.HasSuffix(requirement, "// indirect") {
+			continue
+		}
+		modules = append(modules, strings.Fields(requirement)[0])
+	}
+	return modules
+}
@@ -0,0 +1,95 @@
+package credit
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// section groups the credits of one kind of asset or of libraries
+type section struct {
+	Title   string
+	Entries []entry
+}
+
+type entry struct {
+	Name string
+	Credit
+}
+
+func (report *Report) sections() []section {
+	sections := []section{}
+	for _, asset := range report.Assets {
+		if len(sections) == 0 || sections[len(sections)-1].Title != asset.Category() {
+			sections = append(sections, section{Title: asset.Category()})
+		}
+		sections[len(sections)-1].Entries = append(sections[len(sections)-1].Entries, entry{
+			Name:   asset.Path,
+			Credit: asset.Credit,
+		})
+	}
+
+	libraries := section{Title: "Programming libraries"}
+	for _, library := range report.Libraries {
+		libraries.Entries = append(libraries.Entries, entry{
+			Name:   library.Name + " (" + library.Module + ")",
+			Credit: library.Credit,
+		})
+	}
+	return append(sections, libraries)
+}
+
+// WriteMarkdown writes the report as a Markdown attribution document
+func (report *Report) WriteMarkdown(w io.Writer) error {
+	builder := &strings.Builder{}
+	builder.WriteString("# MichelSpace2000 - Attributions\n")
+	for _, section := range report.sections() {
+		builder.WriteString("\n## " + section.Title + "\n\n")
+		builder.WriteString("| Name | Authors | License | Source |\n")
+		builder.WriteString("| --- | --- | --- | --- |\n")
+		for _, entry := range section.Entries {
+			fmt.Fprintf(builder, "| %s | %s | %s | <%s> |\n",
+				escapeMarkdownCell(entry.Name),
+				escapeMarkdownCell(strings.Join(entry.Authors, ", ")),
+				escapeMarkdownCell(entry.License),
+				entry.Source,
+			)
+		}
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"join": strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>MichelSpace2000 - Attributions</title>
+</head>
+<body>
+<h1>MichelSpace2000 - Attributions</h1>
+{{- range .}}
+<h2>{{.Title}}</h2>
+<table>
+<tr><th>Name</th><th>Authors</th><th>License</th><th>Source</th></tr>
+{{- range .Entries}}
+<tr><td>{{.Name}}</td><td>{{join .Authors ", "}}</td><td>{{.License}}</td><td><a href="{{.Source}}">{{.Source}}</a></td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as an HTML attribution page
+func (report *Report) WriteHTML(w io.Writer) error {
+	return htmlReportTemplate.Execute(w, report.sections())
+}
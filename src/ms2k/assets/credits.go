@@ -1,8 +1,6 @@
 package assets
 
+import "github.com/RemiEven/michelSpace2000/src/ms2k/assets/credit"
+
 // Credit holds information about where an asset was found, who created it and how it is licensed
-type Credit struct {
-	Authors []string `json:"authors"`
-	Source  string   `json:"source"`
-	License string   `json:"license"`
-}
+type Credit = credit.Credit
@@ -0,0 +1,50 @@
+package assets
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// mod is a named set of files overriding some of the embedded assets
+type mod struct {
+	name string
+	fsys fs.FS
+}
+
+// moddedFS layers the files of enabled mods over the embedded assets
+type moddedFS struct {
+	base fs.FS
+	mods []mod // sorted by increasing priority
+}
+
+func newModdedFS(mods []mod) (*moddedFS, error) {
+	base, err := fs.Sub(assetFS, "files")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded assets: %w", err)
+	}
+	return &moddedFS{
+		base: base,
+		mods: mods,
+	}, nil
+}
+
+// ReadFile reads the file at the given path from the mod with the highest priority that contains it,
+// falling back on embedded assets. It also returns the name of the mod the file was read from, if any.
+func (mfs *moddedFS) ReadFile(path string) (content []byte, modName string, err error) {
+	for i := len(mfs.mods) - 1; i >= 0; i-- {
+		content, err := fs.ReadFile(mfs.mods[i].fsys, path)
+		if err == nil {
+			return content, mfs.mods[i].name, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, "", fmt.Errorf("failed to read path [%s] from mod [%s]: %w", path, mfs.mods[i].name, err)
+		}
+	}
+
+	content, err = fs.ReadFile(mfs.base, path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read path [%s]: %w", path, err)
+	}
+	return content, "", nil
+}
@@ -0,0 +1,50 @@
+package assets
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// Progress tracks how many assets and bytes have been loaded so far.
+// It is safe to read it while assets are being loaded.
+type Progress struct {
+	totalAssets  atomic.Int64
+	loadedAssets atomic.Int64
+
+	expectedBytes atomic.Int64
+	loadedBytes   atomic.Int64
+}
+
+// Assets returns the number of loaded assets and the total number of assets to load
+func (p *Progress) Assets() (loaded, total int) {
+	return int(p.loadedAssets.Load()), int(p.totalAssets.Load())
+}
+
+// Bytes returns the number of loaded bytes and the number of bytes known to be expected so far.
+// The expected number of bytes grows as the size of each asset becomes known.
+func (p *Progress) Bytes() (loaded, expected int64) {
+	return p.loadedBytes.Load(), p.expectedBytes.Load()
+}
+
+// Ratio returns the share of assets that have been loaded, between 0 and 1
+func (p *Progress) Ratio() float64 {
+	loaded, total := p.Assets()
+	if total == 0 {
+		return 0
+	}
+	return float64(loaded) / float64(total)
+}
+
+// countingReader adds the number of bytes it reads to a counter
+type countingReader struct {
+	reader  io.Reader
+	counter *atomic.Int64
+	count   int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.reader.Read(p)
+	cr.counter.Add(int64(n))
+	cr.count += int64(n)
+	return n, err
+}
@@ -0,0 +1,174 @@
+package ms2k
+
+import (
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	defaultMinZoom = 0.125
+	defaultMaxZoom = 4.0
+
+	cameraSmoothingRate = 8.0   // how fast the camera catches up with its target, per second
+	freeLookSpeed       = 600.0 // screen pixels per second
+)
+
+// CameraMode tells what the camera is looking at
+type CameraMode uint8
+
+// Enum of all camera modes
+const (
+	CameraFollow   CameraMode = iota // the camera follows a moving position, such as the selected ship
+	CameraFreeLook                   // the camera is panned by the player
+	CameraFocus                      // the camera looks at a fixed target
+)
+
+// Camera holds what part of the world is displayed on screen, and moves smoothly towards its target
+type Camera struct {
+	position Position
+	zoom     float64
+
+	mode           CameraMode
+	targetPosition Position
+	targetZoom     float64
+
+	minZoom, maxZoom float64
+
+	viewportWidth, viewportHeight float64
+
+	panX, panY float64
+
+	lastUpdate time.Duration
+}
+
+// NewCamera creates a new camera following the given position
+func NewCamera(position Position, timeNow time.Duration) *Camera {
+	return &Camera{
+		position:       position,
+		zoom:           1,
+		mode:           CameraFollow,
+		targetPosition: position,
+		targetZoom:     1,
+		minZoom:        defaultMinZoom,
+		maxZoom:        defaultMaxZoom,
+		lastUpdate:     timeNow,
+	}
+}
+
+// SetZoomLimits changes the range the zoom is clamped to, and immediately sets the zoom to the given value
+func (c *Camera) SetZoomLimits(minZoom, maxZoom, zoom float64) {
+	c.minZoom, c.maxZoom = minZoom, maxZoom
+	c.zoom = clamp(zoom, minZoom, maxZoom)
+	c.targetZoom = c.zoom
+}
+
+// JumpTo immediately moves the camera to the given position
+func (c *Camera) JumpTo(p Position) {
+	c.position = p
+	c.targetPosition = p
+}
+
+// Update moves the camera towards its target, given the current time of the clock it follows.
+// The followed position is used in follow mode only.
+func (c *Camera) Update(timeNow time.Duration, followedPosition Position) {
+	elapsedSeconds := (timeNow - c.lastUpdate).Seconds()
+	c.lastUpdate = timeNow
+
+	switch c.mode {
+	case CameraFollow:
+		c.targetPosition = followedPosition
+	case CameraFreeLook:
+		c.targetPosition.X += c.panX * freeLookSpeed * elapsedSeconds / c.targetZoom
+		c.targetPosition.Y += c.panY * freeLookSpeed * elapsedSeconds / c.targetZoom
+	}
+	c.panX, c.panY = 0, 0
+
+	progress := 1 - math.Exp(-cameraSmoothingRate*elapsedSeconds)
+	c.position.X += (c.targetPosition.X - c.position.X) * progress
+	c.position.Y += (c.targetPosition.Y - c.position.Y) * progress
+	c.zoom *= math.Pow(c.targetZoom/c.zoom, progress)
+}
+
+// Position returns the position of the world displayed at the center of the screen
+func (c *Camera) Position() Position {
+	return c.position
+}
+
+// Zoom returns the current zoom factor, a zoom of 2 meaning that the world is displayed twice as big
+func (c *Camera) Zoom() float64 {
+	return c.zoom
+}
+
+// Mode returns the current mode of the camera
+func (c *Camera) Mode() CameraMode {
+	return c.mode
+}
+
+// ZoomIn smoothly doubles the zoom, within limits
+func (c *Camera) ZoomIn() {
+	c.targetZoom = clamp(c.targetZoom*2, c.minZoom, c.maxZoom)
+}
+
+// ZoomOut smoothly halves the zoom, within limits
+func (c *Camera) ZoomOut() {
+	c.targetZoom = clamp(c.targetZoom/2, c.minZoom, c.maxZoom)
+}
+
+// Follow makes the camera follow the position given on each update
+func (c *Camera) Follow() {
+	c.mode = CameraFollow
+}
+
+// FreeLook lets the player pan the camera from where it currently looks
+func (c *Camera) FreeLook() {
+	c.mode = CameraFreeLook
+	c.targetPosition = c.position
+}
+
+// FocusOn makes the camera smoothly move to the given target and stay there
+func (c *Camera) FocusOn(target Position) {
+	c.mode = CameraFocus
+	c.targetPosition = target
+}
+
+// Pan moves the camera in the given direction during the next update, in free-look mode only.
+// Each component of the direction should be between -1 and 1.
+func (c *Camera) Pan(dx, dy float64) {
+	c.panX, c.panY = dx, dy
+}
+
+// SetViewport sets the size of the screen area the camera draws to
+func (c *Camera) SetViewport(width, height int) {
+	c.viewportWidth, c.viewportHeight = float64(width), float64(height)
+}
+
+// WorldToScreen converts a position in the world to screen coordinates
+func (c *Camera) WorldToScreen(p Position) (x, y float64) {
+	return (p.X-c.position.X)*c.zoom + c.viewportWidth/2, (p.Y-c.position.Y)*c.zoom + c.viewportHeight/2
+}
+
+// ScreenToWorld converts screen coordinates to a position in the world
+func (c *Camera) ScreenToWorld(x, y float64) Position {
+	return Position{
+		X: (x-c.viewportWidth/2)/c.zoom + c.position.X,
+		Y: (y-c.viewportHeight/2)/c.zoom + c.position.Y,
+	}
+}
+
+// TranslateToScreen adds to geoM the translation from the origin of the world to the given position on screen
+func (c *Camera) TranslateToScreen(geoM *ebiten.GeoM, p Position) {
+	geoM.Translate(c.WorldToScreen(p))
+}
+
+// IsVisible returns whether the given position is on screen, or at most margin world units away from it
+func (c *Camera) IsVisible(p Position, margin float64) bool {
+	topLeft := c.ScreenToWorld(0, 0)
+	bottomRight := c.ScreenToWorld(c.viewportWidth, c.viewportHeight)
+	return isInBox(p.X, p.Y, topLeft.X-margin, bottomRight.X+margin, topLeft.Y-margin, bottomRight.Y+margin)
+}
+
+func clamp(value, minValue, maxValue float64) float64 {
+	return math.Max(minValue, math.Min(maxValue, value))
+}
@@ -1,32 +1,108 @@
 package ms2k
 
-import "math"
+import (
+	"math"
+	"time"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/generation"
+)
+
+const (
+	moonOrbitDistance     = 45.0
+	wormHoleRotationSpeed = -0.6 // radians per second
+)
 
 // Planet holds all information about a planet
 type Planet struct {
-	Name     string
-	Position Position
-	Looted   bool
-	Hue      float64
-	Moons    []*Moon
+	Name          string
+	Nickname      string // only given to notable planets
+	Lore          string // revealed by scanning the planet
+	Position      Position
+	Looted        bool
+	Hue           float64
+	RotationSpeed float64 // radians per second
+	Mass          float64 // relative to a typical planet, which determines how strongly it pulls ships
+	Radius        float64
+	Attributes    generation.PlanetAttributes // revealed by scanning the planet
+	Moons         []*Moon
+}
+
+// DisplayName returns the name of the planet as shown to the player, with its nickname if it has one
+func (planet *Planet) DisplayName() string {
+	if planet.Nickname == "" {
+		return planet.Name
+	}
+	return planet.Nickname + " (" + planet.Name + ")"
 }
 
-func (planet *Planet) AddMoon(angle float64) {
-	distance := 45.0
-	planet.Moons = append(planet.Moons, &Moon{
-		Position: Position{
-			X: planet.Position.X + distance*math.Cos(angle),
-			Y: planet.Position.Y + distance*math.Sin(angle),
-		},
-	})
+// AddMoon adds a moon orbiting the planet, starting at the given angle and turning at the given speed in radians per second
+func (planet *Planet) AddMoon(phase, angularSpeed float64) {
+	moon := &Moon{
+		Distance:     moonOrbitDistance,
+		Phase:        phase,
+		AngularSpeed: angularSpeed,
+	}
+	moon.Position = moon.PositionAt(planet.Position, 0)
+	planet.Moons = append(planet.Moons, moon)
+}
+
+// RotationAt returns the angle the planet has turned by at the given simulation time
+func (planet *Planet) RotationAt(simulationTime time.Duration) float64 {
+	return planet.RotationSpeed * simulationTime.Seconds()
+}
+
+// updateMoons moves the moons of the planet to where they are at the given simulation time
+func (planet *Planet) updateMoons(simulationTime time.Duration) {
+	for _, moon := range planet.Moons {
+		moon.Position = moon.PositionAt(planet.Position, simulationTime)
+	}
 }
 
 // Moon holds all information about a moon
 type Moon struct {
-	Position Position
+	Position Position // current position, updated along the orbit on each simulation step
+	Looted   bool
+
+	Distance     float64 // radius of the orbit
+	Phase        float64 // angle on the orbit at the start of the game
+	AngularSpeed float64 // radians per second
+}
+
+// PositionAt returns where the moon is on its orbit around the given planet position at the given simulation time
+func (moon *Moon) PositionAt(planetPosition Position, simulationTime time.Duration) Position {
+	angle := moon.Phase + moon.AngularSpeed*simulationTime.Seconds()
+	return Position{
+		X: planetPosition.X + moon.Distance*math.Cos(angle),
+		Y: planetPosition.Y + moon.Distance*math.Sin(angle),
+	}
 }
 
 // WormHole holds all information about a worm hole
 type WormHole struct {
 	Position Position
 }
+
+// RotationAt returns the angle the worm hole has turned by at the given simulation time
+func (wormHole *WormHole) RotationAt(simulationTime time.Duration) float64 {
+	return wormHoleRotationSpeed * simulationTime.Seconds()
+}
+
+// Star holds all information about the star at the center of a star system
+type Star struct {
+	Name        string
+	Position    Position
+	Radius      float64
+	Temperature float64 // between 0 for a cold red star and 1 for a hot blue one
+}
+
+// Nebula holds all information about a nebula, a round region of space that hinders ships
+type Nebula struct {
+	Position Position
+	Radius   float64
+	Kind     generation.NebulaKind
+}
+
+// Contains returns whether the given position is inside the nebula
+func (nebula *Nebula) Contains(p Position) bool {
+	return nebula.Position.DistanceTo(&p) < nebula.Radius
+}
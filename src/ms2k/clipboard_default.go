@@ -0,0 +1,56 @@
+//go:build !wasm
+
+package ms2k
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardCommands returns the commands that read from and write to the clipboard on the current platform
+func clipboardCommands() (read, write []string) {
+	switch runtime.GOOS {
+	case "windows":
+		return []string{"powershell", "-NoProfile", "-Command", "Get-Clipboard"}, []string{"clip"}
+	case "darwin":
+		return []string{"pbpaste"}, []string{"pbcopy"}
+	default:
+		if _, err := exec.LookPath("wl-paste"); err == nil {
+			return []string{"wl-paste", "--no-newline"}, []string{"wl-copy"}
+		}
+		return []string{"xclip", "-selection", "clipboard", "-o"}, []string{"xclip", "-selection", "clipboard"}
+	}
+}
+
+// readClipboard reads the text of the clipboard in the background.
+// The returned channel receives the text, or is closed without a value if it cannot be read.
+func readClipboard() <-chan string {
+	textChan := make(chan string, 1)
+	go func() {
+		defer close(textChan)
+		read, _ := clipboardCommands()
+		output, err := exec.Command(read[0], read[1:]...).Output()
+		if err != nil {
+			log.Println(fmt.Errorf("failed to read clipboard: %w", err))
+			return
+		}
+		textChan <- strings.TrimSpace(string(output))
+	}()
+	return textChan
+}
+
+// writeClipboard writes the given text to the clipboard in the background
+func writeClipboard(text string) {
+	go func() {
+		_, write := clipboardCommands()
+		command := exec.Command(write[0], write[1:]...)
+		command.Stdin = bytes.NewBufferString(text)
+		if err := command.Run(); err != nil {
+			log.Println(fmt.Errorf("failed to write clipboard: %w", err))
+		}
+	}()
+}
@@ -0,0 +1,52 @@
+//go:build wasm
+
+package ms2k
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"syscall/js"
+)
+
+// readClipboard reads the text of the clipboard in the background, which the browser may ask the player to allow.
+// The returned channel receives the text, or is closed without a value if it cannot be read.
+func readClipboard() <-chan string {
+	textChan := make(chan string, 1)
+	clipboard := js.Global().Get("navigator").Get("clipboard")
+	if clipboard.IsUndefined() {
+		log.Println("failed to read clipboard: clipboard API is not available")
+		close(textChan)
+		return textChan
+	}
+
+	var onSuccess, onFailure js.Func
+	release := func() {
+		onSuccess.Release()
+		onFailure.Release()
+	}
+	onSuccess = js.FuncOf(func(this js.Value, args []js.Value) any {
+		defer release()
+		textChan <- strings.TrimSpace(args[0].String())
+		close(textChan)
+		return nil
+	})
+	onFailure = js.FuncOf(func(this js.Value, args []js.Value) any {
+		defer release()
+		log.Println(fmt.Errorf("failed to read clipboard: %s", args[0].Call("toString").String()))
+		close(textChan)
+		return nil
+	})
+	clipboard.Call("readText").Call("then", onSuccess, onFailure)
+	return textChan
+}
+
+// writeClipboard writes the given text to the clipboard in the background
+func writeClipboard(text string) {
+	clipboard := js.Global().Get("navigator").Get("clipboard")
+	if clipboard.IsUndefined() {
+		log.Println("failed to write clipboard: clipboard API is not available")
+		return
+	}
+	clipboard.Call("writeText", text)
+}
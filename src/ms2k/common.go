@@ -19,18 +19,3 @@ func (p *Position) DistanceTo(other *Position) float64 {
 func (p *Position) String() string {
 	return fmt.Sprintf("X: %8.0f parsecs\nY: %8.0f parsecs", p.X/10, p.Y/10)
 }
-
-// Direction is an enum used to know towards where an oriented object is pointing
-type Direction int
-
-// Enum of all supported directions
-const (
-	North Direction = iota
-	Northwest
-	West
-	Southwest
-	South
-	Southeast
-	East
-	Northeast
-)
@@ -17,6 +17,10 @@ type KeyMapping struct {
 
 	ZoomIn, ZoomOut ebiten.Key
 
+	FreeLook, FocusEarth ebiten.Key
+
+	ToggleMap ebiten.Key
+
 	Up, Down, Left, Right ebiten.Key
 }
 
@@ -27,6 +31,11 @@ var keyMapping = &KeyMapping{
 	ZoomIn:  ebiten.KeyW,
 	ZoomOut: ebiten.KeyS,
 
+	FreeLook:   ebiten.KeyF,
+	FocusEarth: ebiten.KeyH,
+
+	ToggleMap: ebiten.KeyTab,
+
 	Up:    ebiten.KeyUp,
 	Down:  ebiten.KeyDown,
 	Left:  ebiten.KeyLeft,
@@ -41,6 +50,9 @@ func ebitenKeyToString(keyboardLayout string, key ebiten.Key) string {
 			ebiten.KeyD:     "D",
 			ebiten.KeyW:     "Z",
 			ebiten.KeyS:     "S",
+			ebiten.KeyF:     "F",
+			ebiten.KeyH:     "H",
+			ebiten.KeyTab:   "Tab",
 			ebiten.KeyUp:    "Up",
 			ebiten.KeyDown:  "Down",
 			ebiten.KeyLeft:  "Left",
@@ -52,6 +64,9 @@ func ebitenKeyToString(keyboardLayout string, key ebiten.Key) string {
 			ebiten.KeyD:     "D",
 			ebiten.KeyW:     "W",
 			ebiten.KeyS:     "S",
+			ebiten.KeyF:     "F",
+			ebiten.KeyH:     "H",
+			ebiten.KeyTab:   "Tab",
 			ebiten.KeyUp:    "Up",
 			ebiten.KeyDown:  "Down",
 			ebiten.KeyLeft:  "Left",
@@ -29,49 +29,30 @@ func NewCreditScreen(assetLibrary *assets.Library) *CreditScreen {
 	addParagraph := func(paragraph string) {
 		paragraphs = append(paragraphs, paragraph)
 	}
-
-	assetLibrary.ImagesCredits.Range(func(name string, credit assets.Credit) {
+	addCredit := func(name string, credit assets.Credit) {
+		if credit.Mod != "" {
+			name += " (from mod " + credit.Mod + ")"
+		}
 		addParagraph("- " + name)
 		addParagraph(pseudoTab + "by " + strings.Join(credit.Authors, ", ") + ", used under license " + credit.License)
 		addParagraph(pseudoTab + credit.Source)
-	})
+	}
+
+	assetLibrary.ImagesCredits.Range(addCredit)
 
 	addParagraph("")
 	addParagraph(pseudoTab + pseudoTab + "Fonts")
 
-	assetLibrary.FontFacesCredits.Range(func(name string, credit assets.Credit) {
-		addParagraph("- " + name)
-		addParagraph(pseudoTab + "by " + strings.Join(credit.Authors, ", ") + ", used under license " + credit.License)
-		addParagraph(pseudoTab + credit.Source)
-	})
+	assetLibrary.FontFacesCredits.Range(addCredit)
 
 	addParagraph("")
 	addParagraph(pseudoTab + pseudoTab + "Music and sounds")
 
-	assetLibrary.SoundsCredits.Range(func(name string, credit assets.Credit) {
-		addParagraph("- " + name)
-		addParagraph(pseudoTab + "by " + strings.Join(credit.Authors, ", ") + ", used under license " + credit.License)
-		addParagraph(pseudoTab + credit.Source)
-	})
+	assetLibrary.SoundsCredits.Range(addCredit)
 
 	addParagraph("")
 	addParagraph(pseudoTab + pseudoTab + "Programming libraries")
-	for name, credit := range map[string]assets.Credit{
-		"ebiten": {
-			Authors: []string{"hajimehoshi"},
-			License: "Apache-2.0",
-			Source:  "https://pkg.go.dev/github.com/hajimehoshi/ebiten/v2",
-		},
-		"opensimplex-go": {
-			Authors: []string{"ojrac"},
-			License: "Unlicense",
-			Source:  "https://pkg.go.dev/github.com/ojrac/opensimplex-go",
-		},
-	} {
-		addParagraph("- " + name)
-		addParagraph(pseudoTab + "by " + strings.Join(credit.Authors, ", ") + ", used under license " + credit.License)
-		addParagraph(pseudoTab + credit.Source)
-	}
+	assetLibrary.LibrariesCredits.Range(addCredit)
 
 	lines, maxScroll := ui.SplitWallOfText(assetLibrary, 880, 640, paragraphs)
 
@@ -113,7 +94,7 @@ func (cs *CreditScreen) repeatingKeyPressed(key ebiten.Key) bool {
 }
 
 func (cs *CreditScreen) Draw(screen *ebiten.Image) {
-	drawSpaceBackground(screen, cs.assetLibrary, Position{})
+	drawSpaceBackground(screen, cs.assetLibrary, Position{}, 1)
 
 	ui.DrawBoxAround(screen, cs.assetLibrary, 200, 80, 880, 640, ui.AllBorders)
 
@@ -0,0 +1,95 @@
+package ms2k
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/sharecode"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/timing"
+)
+
+// The doomsday clock goes from 23:55 to midnight, when the game is lost.
+// It runs faster when the player does not find anything for a while, and goes back when they scan moons or promising planets.
+const (
+	doomsdayClockSeconds = 5 * 60 // seconds on the clock between the start of the game and midnight
+
+	moonScanBonus         = 3    // percentage of the doomsday clock given back by scanning a moon
+	promisingHabitability = 0.2  // habitability from which scanning a planet gives time back
+	promisingPlanetBonus  = 10.0 // percentage of the doomsday clock given back by scanning a perfectly habitable planet
+
+	idleGracePeriod = 40 * time.Second // simulation time without progress before the doomsday clock speeds up
+	idleRampTime    = 60 * time.Second // simulation time for the doomsday clock to reach its maximal speed up
+	maxIdleSpeedUp  = 1.0              // how much faster the doomsday clock runs when the player idles
+
+	recentClockChangeDuration = 8 * time.Second // how long changes of the doomsday clock are shown in the HUD
+)
+
+// doomsdayClockSpeeds are the speeds of the doomsday clock for each difficulty, in percents per second
+var doomsdayClockSpeeds = [...]float64{
+	sharecode.Relaxed: 3. / 5.,
+	sharecode.Normal:  4. / 5.,
+	sharecode.Hard:    6. / 5.,
+}
+
+// updateDoomsdayClock adapts the speed of the doomsday clock to how long the player has been idle, then updates it
+func (w *World) updateDoomsdayClock() {
+	idleRatio := float64(w.simulation.Now()-w.lastProgressAt-idleGracePeriod) / float64(idleRampTime)
+	speedUp := 1 + maxIdleSpeedUp*math.Max(0, math.Min(1, idleRatio))
+	w.lose.SetSpeed(doomsdayClockSpeeds[w.options.Difficulty] * speedUp)
+	w.lose.Update()
+}
+
+// recordProgress tells the doomsday clock that the player found something, so that it stops speeding up
+func (w *World) recordProgress() {
+	w.lastProgressAt = w.simulation.Now()
+}
+
+// shiftDoomsdayClock moves the doomsday clock forward by the given percentage, or back if it is negative
+func (w *World) shiftDoomsdayClock(shift float64, reason string) {
+	w.lose.Shift(shift, reason)
+}
+
+// doomsdayClockHUDLines returns the rate of the doomsday clock and its recent changes
+func (w *World) doomsdayClockHUDLines() []string {
+	rate := w.lose.Speed() / doomsdayClockSpeeds[w.options.Difficulty]
+	rateLine := "Clock rate: x" + strconv.FormatFloat(rate, 'f', 1, 64)
+	if rate > 1 {
+		rateLine += " (no discovery)"
+	}
+
+	lines := []string{rateLine}
+	for _, change := range w.lose.History() {
+		if w.simulation.Since(change.At) < recentClockChangeDuration {
+			lines = append(lines, formatClockShift(change.Amount)+" "+change.Reason)
+		}
+	}
+	return lines
+}
+
+// formatClockShift formats a shift of the doomsday clock as the time it adds or removes
+func formatClockShift(percentage float64) string {
+	sign := "+"
+	if percentage < 0 {
+		sign = "-"
+	}
+	seconds := int(math.Round(math.Abs(percentage) * doomsdayClockSeconds / 100))
+	return fmt.Sprintf("%s%d:%02d", sign, seconds/60, seconds%60)
+}
+
+// doomsdayClockTime returns the time shown by the doomsday clock for its progress
+func doomsdayClockTime(progress *timing.Progress) string {
+	secondsPerPercent := doomsdayClockSeconds / 100
+	// a shift may push the progress past midnight before the game is lost
+	percentage := min(100, progress.Percentage())
+
+	minutes := 55 + int(float64(secondsPerPercent)*percentage)/60
+	seconds := int(float64(secondsPerPercent)*percentage) % 60
+
+	if minutes >= 60 {
+		return "Midnight"
+	}
+
+	return fmt.Sprintf("23:%02d:%02d", minutes, seconds)
+}
@@ -0,0 +1,27 @@
+package ms2k
+
+import (
+	"testing"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/timing"
+)
+
+func TestDoomsdayClockTime(t *testing.T) {
+	for _, testCase := range []struct {
+		percentage float64
+		expected   string
+	}{
+		{0, "23:55:00"},
+		{50, "23:57:30"},
+		{99.9, "23:59:59"},
+		{100, "Midnight"},
+		{106, "Midnight"},
+		{125, "Midnight"},
+	} {
+		progress := timing.NewProgress(timing.NewClock(), 0)
+		progress.Shift(testCase.percentage, "test")
+		if clockTime := doomsdayClockTime(progress); clockTime != testCase.expected {
+			t.Errorf("unexpected time at [%v] percents: wanted [%s], got [%s]", testCase.percentage, testCase.expected, clockTime)
+		}
+	}
+}
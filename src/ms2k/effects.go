@@ -0,0 +1,186 @@
+package ms2k
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/particles"
+)
+
+const (
+	maxParticles         = 2048
+	engineExhaustOffset  = 12     // distance between the center of a ship and its engine
+	wormHoleSwirlRadius  = 36     // distance from the center of a worm hole at which its particles appear
+	lootBurstParticles   = 48     // number of particles of the burst shown when a planet is scanned
+	scanBeamParticleRate = 40     // particles per second
+	engineParticleRate   = 60     // particles per second
+	wormHoleParticleRate = 30     // particles per second
+	scanBeamTravelTime   = 400    // milliseconds for a scan beam particle to reach the planet
+	wormHoleSwirlRange   = 1200.0 // distance to a ship under which worm holes swirl, so that it does not depend on what is on screen
+)
+
+// worldEffects holds the particle effects of the world and the emitters attached to its objects
+type worldEffects struct {
+	seed             int64
+	system           *particles.System
+	engineEmitters   map[*Ship]*particles.Emitter
+	scanEmitters     map[*Ship]*particles.Emitter
+	wormHoleEmitters map[*WormHole]*particles.Emitter
+
+	scanTargets map[*Ship]Position
+
+	particleImage *ebiten.Image
+}
+
+func newWorldEffects(seed int64) *worldEffects {
+	particleImage := ebiten.NewImage(1, 1)
+	particleImage.Fill(color.White)
+	return &worldEffects{
+		seed:             seed,
+		system:           particles.NewSystem(seed, maxParticles),
+		engineEmitters:   map[*Ship]*particles.Emitter{},
+		scanEmitters:     map[*Ship]*particles.Emitter{},
+		wormHoleEmitters: map[*WormHole]*particles.Emitter{},
+		scanTargets:      map[*Ship]Position{},
+		particleImage:    particleImage,
+	}
+}
+
+// update moves the emitters along with the world objects and advances the particles to the given simulation time
+func (we *worldEffects) update(w *World, simulationTime time.Duration) {
+	for i, ship := range w.Ships {
+		engineEmitter, ok := we.engineEmitters[ship]
+		if !ok {
+			engineEmitter = we.system.NewEmitter(0, 0, engineParticleRate, we.seed+int64(2*i), engineSpawner(ship))
+			we.engineEmitters[ship] = engineEmitter
+		}
+		engineEmitter.Active = ship.thrusting
+		forward := ship.Forward()
+		engineEmitter.X = ship.Position.X - forward.X*engineExhaustOffset
+		engineEmitter.Y = ship.Position.Y - forward.Y*engineExhaustOffset
+
+		scanEmitter, ok := we.scanEmitters[ship]
+		if !ok {
+			scanEmitter = we.system.NewEmitter(0, 0, scanBeamParticleRate, we.seed+int64(2*i+1), we.scanBeamSpawner(ship))
+			we.scanEmitters[ship] = scanEmitter
+		}
+		scannedPlanet := ship.scannedPlanet()
+		scanEmitter.Active = scannedPlanet != nil
+		scanEmitter.X, scanEmitter.Y = ship.Position.X, ship.Position.Y
+		if scannedPlanet != nil {
+			we.scanTargets[ship] = scannedPlanet.Position
+		}
+	}
+
+	for _, wormHole := range w.WormHoles {
+		swirling := w.isExplored(wormHole.Position) && isNearAShip(w.Ships, wormHole.Position, wormHoleSwirlRange)
+		wormHoleEmitter, ok := we.wormHoleEmitters[wormHole]
+		switch {
+		case swirling && !ok:
+			seed := we.seed ^ int64(math.Float64bits(wormHole.Position.X)) ^ int64(math.Float64bits(wormHole.Position.Y))<<1
+			we.wormHoleEmitters[wormHole] = we.system.NewEmitter(wormHole.Position.X, wormHole.Position.Y, wormHoleParticleRate, seed, wormHoleSpawner)
+		case !swirling && ok:
+			we.system.RemoveEmitter(wormHoleEmitter)
+			delete(we.wormHoleEmitters, wormHole)
+		}
+	}
+
+	we.system.Update(simulationTime)
+}
+
+// isNearAShip returns whether one of the ships is closer than the given distance to a position
+func isNearAShip(ships []*Ship, position Position, distance float64) bool {
+	for _, ship := range ships {
+		if ship.Position.DistanceTo(&position) < distance {
+			return true
+		}
+	}
+	return false
+}
+
+// burst shows particles of the given color flying away from a celestial body that has just been scanned
+func (we *worldEffects) burst(position Position, clr color.Color) {
+	we.system.Burst(position.X, position.Y, lootBurstParticles, lootBurstSpawner(clr))
+}
+
+// draw draws all particles as small squares
+func (we *worldEffects) draw(screen *ebiten.Image, camera *Camera) {
+	zoom := camera.Zoom()
+	we.system.Range(func(p *particles.Particle) {
+		position := Position{X: p.X, Y: p.Y}
+		if !camera.IsVisible(position, 0) {
+			return
+		}
+		size := p.Scale() * zoom
+		dio := &ebiten.DrawImageOptions{}
+		dio.GeoM.Scale(size, size)
+		dio.GeoM.Translate(-size/2, -size/2)
+		camera.TranslateToScreen(&dio.GeoM, position)
+		dio.ColorScale.ScaleWithColor(p.Color())
+		screen.DrawImage(we.particleImage, dio)
+	})
+}
+
+func engineSpawner(ship *Ship) particles.Spawner {
+	return func(r *rand.Rand, p *particles.Particle) {
+		forward := ship.Forward()
+		forwardX, forwardY := forward.X, forward.Y
+		speed := 40 + r.Float64()*40
+		spread := (r.Float64() - 0.5) * 0.6
+		p.VX = -(forwardX*math.Cos(spread) - forwardY*math.Sin(spread)) * speed
+		p.VY = -(forwardX*math.Sin(spread) + forwardY*math.Cos(spread)) * speed
+		p.Drag = 1.5
+		p.Lifetime = 300*time.Millisecond + time.Duration(r.Int63n(int64(300*time.Millisecond)))
+		p.StartColor = color.NRGBA{R: 0xff, G: 0xc0, B: 0x40, A: 0xff}
+		p.EndColor = color.NRGBA{R: 0x80, G: 0x10, B: 0x00, A: 0x00}
+		p.StartScale, p.EndScale = 4, 1
+	}
+}
+
+func (we *worldEffects) scanBeamSpawner(ship *Ship) particles.Spawner {
+	return func(r *rand.Rand, p *particles.Particle) {
+		target := we.scanTargets[ship]
+		travelTime := scanBeamTravelTime * time.Millisecond
+		jitterX, jitterY := (r.Float64()-0.5)*8, (r.Float64()-0.5)*8
+		p.VX = (target.X + jitterX - p.X) / travelTime.Seconds()
+		p.VY = (target.Y + jitterY - p.Y) / travelTime.Seconds()
+		p.Lifetime = travelTime
+		p.StartColor = color.NRGBA{R: 0x60, G: 0xff, B: 0xe0, A: 0xc0}
+		p.EndColor = color.NRGBA{R: 0x60, G: 0xa0, B: 0xff, A: 0x40}
+		p.StartScale, p.EndScale = 2, 3
+	}
+}
+
+func wormHoleSpawner(r *rand.Rand, p *particles.Particle) {
+	angle := r.Float64() * 2 * math.Pi
+	centerX, centerY := p.X, p.Y
+	p.X += math.Cos(angle) * wormHoleSwirlRadius
+	p.Y += math.Sin(angle) * wormHoleSwirlRadius
+	// particles spiral towards the center, reaching it at the end of their life
+	p.Lifetime = time.Second + time.Duration(r.Int63n(int64(500*time.Millisecond)))
+	inwardSpeed := wormHoleSwirlRadius / p.Lifetime.Seconds()
+	tangentialSpeed := 2 * inwardSpeed
+	p.VX = (centerX-p.X)/wormHoleSwirlRadius*inwardSpeed - math.Sin(angle)*tangentialSpeed
+	p.VY = (centerY-p.Y)/wormHoleSwirlRadius*inwardSpeed + math.Cos(angle)*tangentialSpeed
+	p.StartColor = color.NRGBA{R: 0xd0, G: 0x80, B: 0xff, A: 0x00}
+	p.EndColor = color.NRGBA{R: 0x60, G: 0x20, B: 0xa0, A: 0xff}
+	p.StartScale, p.EndScale = 3, 1
+}
+
+func lootBurstSpawner(clr color.Color) particles.Spawner {
+	startColor := color.NRGBAModel.Convert(clr).(color.NRGBA)
+	endColor := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x00}
+	return func(r *rand.Rand, p *particles.Particle) {
+		angle := r.Float64() * 2 * math.Pi
+		speed := 60 + r.Float64()*120
+		p.VX, p.VY = math.Cos(angle)*speed, math.Sin(angle)*speed
+		p.Drag = 1
+		p.Lifetime = 600*time.Millisecond + time.Duration(r.Int63n(int64(600*time.Millisecond)))
+		p.StartColor, p.EndColor = startColor, endColor
+		p.StartScale, p.EndScale = 5, 2
+	}
+}
@@ -0,0 +1,212 @@
+// Package events schedules the random events that happen during a game, such as solar flares or distress beacons.
+// Events only depend on the random source they are drawn from and on when and where they are asked for,
+// so that a game played the same way with the same seed sees the same events.
+package events
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Kind is the kind of an event
+type Kind uint8
+
+// Enum of all event kinds
+const (
+	SolarFlare     Kind = iota // pauses scans for a while
+	AsteroidField              // slows down ships inside it
+	DistressBeacon             // gives time back to the ship that answers it
+	NewsBulletin               // moves the doomsday clock forward or back
+)
+
+// String returns the name of the event kind
+func (kind Kind) String() string {
+	names := [...]string{"solar flare", "asteroid field", "distress beacon", "news bulletin"}
+	if int(kind) >= len(names) {
+		return "unknown"
+	}
+	return names[kind]
+}
+
+const (
+	firstEventDelay = 45 * time.Second
+	minEventDelay   = 40 * time.Second
+	maxEventDelay   = 80 * time.Second
+	asteroidDensity = 0.0002 // asteroids per square unit of an asteroid field
+)
+
+// Event is something that happens during a game
+type Event struct {
+	Kind     Kind
+	Start    time.Duration // simulation time
+	Duration time.Duration // zero for events that happen at once
+	X, Y     float64       // center of asteroid fields and distress beacons
+	Radius   float64
+	// ClockShift is the number of percents added to the doomsday clock by a news bulletin, or removed from it by answering a distress beacon
+	ClockShift float64
+	Seed       int64 // to decorate the event
+	Message    string
+	Asteroids  []Asteroid // of asteroid fields
+}
+
+// Asteroid is a rock of an asteroid field, slowly drifting around its center
+type Asteroid struct {
+	Angle    float64 // radians, when the simulation started
+	Distance float64 // to the center of the field
+	Drift    float64 // radians per second
+	Size     float64
+}
+
+// PositionAt returns the position of the asteroid at the given simulation time
+func (asteroid *Asteroid) PositionAt(event *Event, simulationTime time.Duration) (x, y float64) {
+	angle := asteroid.Angle + simulationTime.Seconds()*asteroid.Drift
+	return event.X + asteroid.Distance*math.Cos(angle), event.Y + asteroid.Distance*math.Sin(angle)
+}
+
+// IsActiveAt returns whether the event is ongoing at the given simulation time
+func (event *Event) IsActiveAt(simulationTime time.Duration) bool {
+	return event.Start <= simulationTime && simulationTime < event.Start+event.Duration
+}
+
+// Contains returns whether the given position is in the area of the event
+func (event *Event) Contains(x, y float64) bool {
+	return math.Hypot(x-event.X, y-event.Y) < event.Radius
+}
+
+// Scheduler draws events at random intervals
+type Scheduler struct {
+	source    *rand.Rand
+	nextEvent time.Duration
+	active    []*Event
+}
+
+// NewScheduler creates a scheduler drawing events from the given source
+func NewScheduler(source *rand.Rand) *Scheduler {
+	return &Scheduler{
+		source:    source,
+		nextEvent: firstEventDelay,
+	}
+}
+
+// Update forgets the events that are over and starts a new one when it is due.
+// Events with an area are placed around the given position. It returns the event that started, if any.
+func (scheduler *Scheduler) Update(simulationTime time.Duration, x, y float64) *Event {
+	active := scheduler.active[:0]
+	for _, event := range scheduler.active {
+		if simulationTime < event.Start+event.Duration {
+			active = append(active, event)
+		}
+	}
+	scheduler.active = active
+
+	if simulationTime < scheduler.nextEvent {
+		return nil
+	}
+	scheduler.nextEvent = simulationTime + minEventDelay + time.Duration(scheduler.source.Int63n(int64(maxEventDelay-minEventDelay)))
+
+	event := scheduler.drawEvent(simulationTime, x, y)
+	if event.Duration > 0 {
+		scheduler.active = append(scheduler.active, event)
+	}
+	return event
+}
+
+// Active returns the ongoing events
+func (scheduler *Scheduler) Active() []*Event {
+	return scheduler.active
+}
+
+// IsActive returns whether an event of the given kind is ongoing
+func (scheduler *Scheduler) IsActive(kind Kind) bool {
+	for _, event := range scheduler.active {
+		if event.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// End ends an event before its time, such as a distress beacon that was answered
+func (scheduler *Scheduler) End(event *Event) {
+	for i, activeEvent := range scheduler.active {
+		if activeEvent == event {
+			scheduler.active = append(scheduler.active[:i], scheduler.active[i+1:]...)
+			return
+		}
+	}
+}
+
+func (scheduler *Scheduler) drawEvent(simulationTime time.Duration, x, y float64) *Event {
+	source := scheduler.source
+	event := &Event{
+		Start: simulationTime,
+		Seed:  source.Int63(),
+	}
+	placeAround := func(minDistance, maxDistance float64) {
+		angle := source.Float64() * 2 * math.Pi
+		distance := minDistance + source.Float64()*(maxDistance-minDistance)
+		event.X, event.Y = x+distance*math.Cos(angle), y+distance*math.Sin(angle)
+	}
+
+	switch roll := source.Float64(); {
+	case roll < 0.25:
+		event.Kind = SolarFlare
+		event.Duration = randomDuration(source, 15*time.Second, 25*time.Second)
+		event.Message = pick(source, flareMessages)
+	case roll < 0.55:
+		event.Kind = AsteroidField
+		event.Duration = randomDuration(source, 90*time.Second, 150*time.Second)
+		event.Radius = 250 + source.Float64()*200
+		placeAround(400, 900)
+		event.Message = pick(source, asteroidMessages)
+		event.Asteroids = drawAsteroids(event.Seed, event.Radius)
+	case roll < 0.8:
+		event.Kind = DistressBeacon
+		event.Duration = randomDuration(source, 100*time.Second, 140*time.Second)
+		event.Radius = 60
+		event.ClockShift = 6 + float64(source.Intn(5))
+		placeAround(900, 1600)
+		event.Message = pick(source, beaconMessages) + " Its signal comes from the " + compassDirection(event.X-x, event.Y-y) + "."
+	default:
+		event.Kind = NewsBulletin
+		if source.Float64() < 0.5 {
+			event.ClockShift = -3 - float64(source.Intn(4))
+			event.Message = pick(source, goodNews)
+		} else {
+			event.ClockShift = 3 + float64(source.Intn(4))
+			event.Message = pick(source, badNews)
+		}
+	}
+	return event
+}
+
+// drawAsteroids places the asteroids of a field from its own seed, so that their number does not change the next events
+func drawAsteroids(seed int64, radius float64) []Asteroid {
+	source := rand.New(rand.NewSource(seed))
+	asteroids := make([]Asteroid, int(asteroidDensity*math.Pi*radius*radius))
+	for i := range asteroids {
+		asteroids[i] = Asteroid{
+			Angle:    source.Float64() * 2 * math.Pi,
+			Distance: radius * math.Sqrt(source.Float64()),
+			Drift:    (source.Float64() - 0.5) * 0.2,
+			Size:     2 + 4*source.Float64(),
+		}
+	}
+	return asteroids
+}
+
+func randomDuration(source *rand.Rand, min, max time.Duration) time.Duration {
+	return min + time.Duration(source.Int63n(int64(max-min)))
+}
+
+func pick(source *rand.Rand, messages []string) string {
+	return messages[source.Intn(len(messages))]
+}
+
+// compassDirection returns the name of the direction of the given vector, north being up on screen
+func compassDirection(dx, dy float64) string {
+	directions := [...]string{"east", "south-east", "south", "south-west", "west", "north-west", "north", "north-east"}
+	sector := int(math.Round(math.Atan2(dy, dx)/(math.Pi/4))+8) % 8
+	return directions[sector]
+}
@@ -0,0 +1,125 @@
+package events
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// run updates a scheduler every simulated second and returns the events that started
+func run(scheduler *Scheduler, duration time.Duration) []*Event {
+	started := []*Event{}
+	for t := time.Duration(0); t < duration; t += time.Second {
+		if event := scheduler.Update(t, 100, -100); event != nil {
+			started = append(started, event)
+		}
+	}
+	return started
+}
+
+func TestEventsAreReproducible(t *testing.T) {
+	first := run(NewScheduler(rand.New(rand.NewSource(42))), 20*time.Minute)
+	second := run(NewScheduler(rand.New(rand.NewSource(42))), 20*time.Minute)
+	if len(first) != len(second) {
+		t.Errorf("the same source should draw the same number of events: got [%d] and [%d]", len(first), len(second))
+		return
+	}
+	for i := range first {
+		if !reflect.DeepEqual(first[i], second[i]) {
+			t.Errorf("the same source should draw the same events: got [%+v] and [%+v]", *first[i], *second[i])
+			return
+		}
+	}
+}
+
+func TestEventsAreSpreadOverTime(t *testing.T) {
+	started := run(NewScheduler(rand.New(rand.NewSource(7))), 20*time.Minute)
+	if len(started) < 20*60/int(maxEventDelay.Seconds()) || len(started) > 20*60/int(minEventDelay.Seconds())+1 {
+		t.Errorf("unexpected number of events in 20 minutes: got [%d]", len(started))
+		return
+	}
+	if started[0].Start < firstEventDelay {
+		t.Errorf("the first event should leave time to the player: it started at [%v]", started[0].Start)
+	}
+
+	kinds := map[Kind]int{}
+	for _, event := range started {
+		kinds[event.Kind]++
+	}
+	if len(kinds) != 4 {
+		t.Errorf("every kind of event should happen in a long game: got [%v]", kinds)
+	}
+}
+
+func TestActiveEvents(t *testing.T) {
+	scheduler := NewScheduler(rand.New(rand.NewSource(1)))
+	flare := &Event{Kind: SolarFlare, Start: 0, Duration: 10 * time.Second}
+	beacon := &Event{Kind: DistressBeacon, Start: 0, Duration: time.Minute}
+	scheduler.active = []*Event{flare, beacon}
+
+	scheduler.Update(5*time.Second, 0, 0)
+	if !scheduler.IsActive(SolarFlare) {
+		t.Errorf("the solar flare should still be active")
+		return
+	}
+	scheduler.Update(15*time.Second, 0, 0)
+	if scheduler.IsActive(SolarFlare) || !scheduler.IsActive(DistressBeacon) {
+		t.Errorf("only the distress beacon should still be active: got [%v]", scheduler.Active())
+		return
+	}
+	scheduler.End(beacon)
+	if len(scheduler.Active()) != 0 {
+		t.Errorf("no event should be active once the beacon is answered: got [%v]", scheduler.Active())
+	}
+}
+
+func TestAsteroidsAreDrawnOnce(t *testing.T) {
+	fields := 0
+	for _, event := range run(NewScheduler(rand.New(rand.NewSource(7))), 20*time.Minute) {
+		if event.Kind != AsteroidField {
+			if len(event.Asteroids) != 0 {
+				t.Errorf("only asteroid fields should have asteroids: got [%d] in a [%s]", len(event.Asteroids), event.Kind)
+				return
+			}
+			continue
+		}
+		fields++
+		if expected := int(asteroidDensity * math.Pi * event.Radius * event.Radius); len(event.Asteroids) != expected {
+			t.Errorf("unexpected number of asteroids: wanted [%d], got [%d]", expected, len(event.Asteroids))
+			return
+		}
+		for _, asteroid := range event.Asteroids {
+			if x, y := asteroid.PositionAt(event, event.Start+event.Duration); !event.Contains(x, y) {
+				t.Errorf("asteroids should stay in their field: got one at [%v %v]", x, y)
+				return
+			}
+		}
+	}
+	if fields == 0 {
+		t.Errorf("a long game should have asteroid fields")
+	}
+}
+
+func TestUnknownKindName(t *testing.T) {
+	if name := Kind(42).String(); name != "unknown" {
+		t.Errorf("unexpected name of an unknown kind: got [%s]", name)
+	}
+}
+
+func TestCompassDirection(t *testing.T) {
+	for _, testCase := range []struct {
+		dx, dy   float64
+		expected string
+	}{
+		{1, 0, "east"},
+		{0, -1, "north"},
+		{-1, 1, "south-west"},
+		{-1, -0.01, "west"},
+	} {
+		if direction := compassDirection(testCase.dx, testCase.dy); direction != testCase.expected {
+			t.Errorf("unexpected direction of [%v %v]: wanted [%s], got [%s]", testCase.dx, testCase.dy, testCase.expected, direction)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package events
+
+var (
+	flareMessages = []string{
+		"Solar flare! Charged particles blind our sensors: scans are paused until it passes.",
+		"A nearby star just erupted. Scanners will be useless for a few seconds.",
+	}
+	asteroidMessages = []string{
+		"Our probes detected a drifting asteroid field. Ships will have to slow down inside it.",
+		"Debris from a shattered moon is crossing the sector. Navigate carefully through the asteroids.",
+	}
+	beaconMessages = []string{
+		"A distress beacon is calling for help. The crew we rescue could share their star charts.",
+		"Someone out there is sending an old emergency signal. Answering it may be worth the detour.",
+	}
+	goodNews = []string{
+		"News from Earth: engineers managed to reinforce the shields of the cities. We have a little more time.",
+		"News from Earth: a new treaty stopped the riots. The situation is stabilizing, for now.",
+		"News from Earth: volunteers built more shelters than expected. Hope is spreading.",
+	}
+	badNews = []string{
+		"News from Earth: the main reactor of the capital has failed. Time is running out faster.",
+		"News from Earth: a second wave of quakes hit the continents. Hurry!",
+		"News from Earth: panic is spreading and supplies are being looted. We must act fast.",
+	}
+)
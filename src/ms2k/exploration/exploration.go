@@ -0,0 +1,125 @@
+// Package exploration keeps track of which parts of the world have been seen by the player
+package exploration
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// formatVersion is written first when marshaling a map, so that older saves can still be read if the format changes
+const formatVersion = 1
+
+// Cell identifies a square cell of the world
+type Cell struct {
+	X, Y int
+}
+
+// Map is a layer of explored cells over the world
+type Map struct {
+	cellSize float64
+	explored map[Cell]struct{}
+}
+
+// NewMap creates a new map where nothing is explored, dividing the world in square cells of the given size
+func NewMap(cellSize float64) *Map {
+	return &Map{
+		cellSize: cellSize,
+		explored: map[Cell]struct{}{},
+	}
+}
+
+// CellAt returns the cell containing the given position
+func (m *Map) CellAt(x, y float64) Cell {
+	return Cell{
+		X: int(math.Floor(x / m.cellSize)),
+		Y: int(math.Floor(y / m.cellSize)),
+	}
+}
+
+// Explore marks as explored all cells whose center is within the given radius of the given position,
+// as well as the cell containing the position
+func (m *Map) Explore(x, y, radius float64) {
+	m.explored[m.CellAt(x, y)] = struct{}{}
+
+	minCell, maxCell := m.CellAt(x-radius, y-radius), m.CellAt(x+radius, y+radius)
+	for cellX := minCell.X; cellX <= maxCell.X; cellX++ {
+		for cellY := minCell.Y; cellY <= maxCell.Y; cellY++ {
+			centerX, centerY := (float64(cellX)+0.5)*m.cellSize, (float64(cellY)+0.5)*m.cellSize
+			if math.Hypot(centerX-x, centerY-y) <= radius {
+				m.explored[Cell{X: cellX, Y: cellY}] = struct{}{}
+			}
+		}
+	}
+}
+
+// IsExplored returns whether the cell containing the given position has been explored
+func (m *Map) IsExplored(x, y float64) bool {
+	_, ok := m.explored[m.CellAt(x, y)]
+	return ok
+}
+
+// ExploredCells returns the number of explored cells
+func (m *Map) ExploredCells() int {
+	return len(m.explored)
+}
+
+// Coverage returns the share of the given number of cells that has been explored, between 0 and 1
+func (m *Map) Coverage(totalCells int) float64 {
+	if totalCells <= 0 {
+		return 0
+	}
+	return math.Min(1, float64(len(m.explored))/float64(totalCells))
+}
+
+// MarshalBinary encodes the map so that it can be saved along with the rest of the world
+func (m *Map) MarshalBinary() ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	buffer.WriteByte(formatVersion)
+	_ = binary.Write(buffer, binary.LittleEndian, m.cellSize)
+	_ = binary.Write(buffer, binary.LittleEndian, uint32(len(m.explored)))
+	for cell := range m.explored {
+		_ = binary.Write(buffer, binary.LittleEndian, [2]int32{int32(cell.X), int32(cell.Y)})
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a map encoded by MarshalBinary, replacing the content of m
+func (m *Map) UnmarshalBinary(data []byte) error {
+	reader := bytes.NewReader(data)
+	version, err := reader.ReadByte()
+	if err != nil {
+		return errors.New("missing format version")
+	}
+	if version != formatVersion {
+		return fmt.Errorf("unsupported format version %v", version)
+	}
+
+	var (
+		cellSize      float64
+		numberOfCells uint32
+	)
+	if err := binary.Read(reader, binary.LittleEndian, &cellSize); err != nil {
+		return fmt.Errorf("failed to read cell size: %w", err)
+	}
+	if err := binary.Read(reader, binary.LittleEndian, &numberOfCells); err != nil {
+		return fmt.Errorf("failed to read number of cells: %w", err)
+	}
+	if int64(numberOfCells)*8 != int64(reader.Len()) {
+		return fmt.Errorf("expected %v cells, but data has %v bytes left", numberOfCells, reader.Len())
+	}
+
+	explored := make(map[Cell]struct{}, numberOfCells)
+	for i := uint32(0); i < numberOfCells; i++ {
+		var coordinates [2]int32
+		if err := binary.Read(reader, binary.LittleEndian, &coordinates); err != nil {
+			return fmt.Errorf("failed to read cell %v: %w", i, err)
+		}
+		explored[Cell{X: int(coordinates[0]), Y: int(coordinates[1])}] = struct{}{}
+	}
+
+	m.cellSize, m.explored = cellSize, explored
+	return nil
+}
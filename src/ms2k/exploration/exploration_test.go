@@ -0,0 +1,82 @@
+package exploration
+
+import "testing"
+
+func TestExplore(t *testing.T) {
+	m := NewMap(10)
+
+	if m.IsExplored(0, 0) {
+		t.Errorf("new map should not have any explored cell")
+		return
+	}
+
+	m.Explore(5, 5, 10)
+	for _, position := range [][2]float64{{5, 5}, {15, 5}, {-5, 5}, {5, -5}, {5, 15}} {
+		if !m.IsExplored(position[0], position[1]) {
+			t.Errorf("position %v should be explored", position)
+			return
+		}
+	}
+	for _, position := range [][2]float64{{15, 15}, {25, 5}, {-15, 5}} {
+		if m.IsExplored(position[0], position[1]) {
+			t.Errorf("position %v should not be explored", position)
+			return
+		}
+	}
+	if m.ExploredCells() != 5 {
+		t.Errorf("unexpected number of explored cells: wanted [%v], got [%v]", 5, m.ExploredCells())
+		return
+	}
+	if coverage := m.Coverage(20); coverage != 0.25 {
+		t.Errorf("unexpected coverage: wanted [%v], got [%v]", 0.25, coverage)
+	}
+}
+
+func TestExploreSmallRadius(t *testing.T) {
+	m := NewMap(10)
+
+	m.Explore(-1, -1, 0)
+	if !m.IsExplored(-9, -9) {
+		t.Errorf("cell containing the exploring position should be explored")
+		return
+	}
+	if m.ExploredCells() != 1 {
+		t.Errorf("unexpected number of explored cells: wanted [%v], got [%v]", 1, m.ExploredCells())
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	m := NewMap(10)
+	m.Explore(-100, 250, 30)
+
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Errorf("failed to marshal map: %v", err)
+		return
+	}
+
+	unmarshaled := &Map{}
+	if err := unmarshaled.UnmarshalBinary(data); err != nil {
+		t.Errorf("failed to unmarshal map: %v", err)
+		return
+	}
+	if unmarshaled.ExploredCells() != m.ExploredCells() {
+		t.Errorf("unexpected number of explored cells: wanted [%v], got [%v]", m.ExploredCells(), unmarshaled.ExploredCells())
+		return
+	}
+	for cell := range m.explored {
+		if _, ok := unmarshaled.explored[cell]; !ok {
+			t.Errorf("cell %v was lost when marshaling", cell)
+			return
+		}
+	}
+
+	if !unmarshaled.IsExplored(-100, 250) || unmarshaled.IsExplored(100, 250) {
+		t.Errorf("the unmarshaled map should keep its cell size")
+		return
+	}
+
+	if err := unmarshaled.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Errorf("expected an error when unmarshaling truncated data")
+	}
+}
@@ -0,0 +1,179 @@
+package ms2k
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/assets"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/palette"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/ui"
+)
+
+const (
+	minimapSize = 200
+	minimapZoom = 1.0 / 16
+
+	galaxyMapMinZoom     = 1.0 / 128
+	galaxyMapMaxZoom     = 1.0 / 2
+	galaxyMapInitialZoom = 1.0 / 16
+
+	planetSpriteHue = 2 * math.Pi / 3 // the planet sprite is mostly green
+)
+
+var (
+	mapBackgroundColor = color.RGBA{R: 0x05, G: 0x05, B: 0x12, A: 0xff}
+	mapChunkColor      = color.RGBA{R: 0x18, G: 0x1c, B: 0x34, A: 0xff}
+	mapEarthColor      = color.RGBA{R: 0x3c, G: 0x8c, B: 0xff, A: 0xff}
+	mapWormHoleColor   = color.RGBA{R: 0xb0, G: 0x50, B: 0xe0, A: 0xff}
+	mapShipColor       = ui.TextColor
+	mapSelectedColor   = ui.SelectedTextColor
+	mapLootedColor     = color.White
+)
+
+// GalaxyMap displays the explored part of the world, either as a minimap in a corner of the screen
+// or as a full screen map that can be panned and zoomed independently of the game camera
+type GalaxyMap struct {
+	open           bool
+	keyboardLayout string
+
+	camera        *Camera
+	minimapCamera *Camera
+	minimapImage  *ebiten.Image
+
+	assetLibrary *assets.Library
+}
+
+// NewGalaxyMap creates a new closed galaxy map
+func NewGalaxyMap(timeNow time.Duration, assetLibrary *assets.Library) *GalaxyMap {
+	galaxyMap := &GalaxyMap{
+		camera:        NewCamera(Position{}, timeNow),
+		minimapCamera: NewCamera(Position{}, timeNow),
+		minimapImage:  ebiten.NewImage(minimapSize, minimapSize),
+		assetLibrary:  assetLibrary,
+	}
+	galaxyMap.camera.SetZoomLimits(galaxyMapMinZoom, galaxyMapMaxZoom, galaxyMapInitialZoom)
+	galaxyMap.minimapCamera.SetZoomLimits(minimapZoom, minimapZoom, minimapZoom)
+	galaxyMap.minimapCamera.SetViewport(minimapSize, minimapSize)
+	return galaxyMap
+}
+
+// IsOpen returns whether the full screen map is displayed
+func (gm *GalaxyMap) IsOpen() bool {
+	return gm.open
+}
+
+// Toggle opens or closes the full screen map. It opens centered on the given position.
+func (gm *GalaxyMap) Toggle(center Position) {
+	gm.open = !gm.open
+	if gm.open {
+		gm.camera.JumpTo(center)
+		gm.camera.FreeLook()
+	}
+}
+
+// Pan pans the full screen map in the given direction during the next update
+func (gm *GalaxyMap) Pan(dx, dy float64) {
+	gm.camera.Pan(dx, dy)
+}
+
+// Update moves the full screen map and keeps the minimap centered on the given position
+func (gm *GalaxyMap) Update(timeNow time.Duration, center Position, keyboardLayout string) {
+	gm.keyboardLayout = keyboardLayout
+	gm.camera.Update(timeNow, center)
+	gm.minimapCamera.Update(timeNow, center)
+}
+
+// ZoomIn zooms in the full screen map
+func (gm *GalaxyMap) ZoomIn() {
+	gm.camera.ZoomIn()
+}
+
+// ZoomOut zooms out the full screen map
+func (gm *GalaxyMap) ZoomOut() {
+	gm.camera.ZoomOut()
+}
+
+// Draw draws either the full screen map if it is open, or the minimap
+func (gm *GalaxyMap) Draw(screen *ebiten.Image, w *World) {
+	if gm.open {
+		gm.camera.SetViewport(screen.Bounds().Dx(), screen.Bounds().Dy())
+		drawMap(screen, w, gm.camera)
+		ui.DrawText(screen, gm.assetLibrary, ui.SmallText, "Galaxy map - "+ebitenKeyToString(gm.keyboardLayout, keyMapping.ToggleMap)+" to close", screen.Bounds().Dx()/2, 4, ui.AlignCenter, ui.TextColor)
+		return
+	}
+
+	gm.minimapImage.Clear()
+	drawMap(gm.minimapImage, w, gm.minimapCamera)
+
+	x, y := screen.Bounds().Dx()-minimapSize, 0
+	ui.DrawBoxAround(screen, gm.assetLibrary, x, y, minimapSize, minimapSize, ui.Left|ui.Bottom)
+	dio := &ebiten.DrawImageOptions{}
+	dio.GeoM.Translate(float64(x), float64(y))
+	screen.DrawImage(gm.minimapImage, dio)
+}
+
+// drawMap draws a schematic view of the generated part of the world as seen by the given camera
+func drawMap(target *ebiten.Image, w *World, camera *Camera) {
+	target.Fill(mapBackgroundColor)
+
+	zoom := float32(camera.Zoom())
+	chunkWorldSize := float64(cellSize * chunkSize)
+	for x, column := range w.GeneratedChunks {
+		for y := range column {
+			chunkX, chunkY := camera.WorldToScreen(Position{X: float64(x) * chunkWorldSize, Y: float64(y) * chunkWorldSize})
+			vector.DrawFilledRect(target, float32(chunkX), float32(chunkY), float32(chunkWorldSize)*zoom+1, float32(chunkWorldSize)*zoom+1, mapChunkColor, false)
+		}
+	}
+
+	drawDot := func(p Position, radius float32, clr color.Color) {
+		x, y := camera.WorldToScreen(p)
+		vector.DrawFilledCircle(target, float32(x), float32(y), radius, clr, true)
+	}
+
+	for _, nebula := range w.Nebulae {
+		drawDot(nebula.Position, float32(nebula.Radius)*zoom, palette.Nebula(nebula.Kind))
+	}
+
+	for _, star := range w.Stars {
+		drawDot(star.Position, 4, palette.Star(star.Temperature))
+	}
+
+	for _, wormHole := range w.WormHoles {
+		if !w.isExplored(wormHole.Position) {
+			drawDot(wormHole.Position, 2, unknownBlipColor)
+			continue
+		}
+		drawDot(wormHole.Position, 3, mapWormHoleColor)
+	}
+
+	for _, planet := range w.Planets[1:] {
+		if !w.isExplored(planet.Position) {
+			drawDot(planet.Position, 2, unknownBlipColor)
+			continue
+		}
+		drawDot(planet.Position, 3, planetMapColor(planet))
+		if planet.Looted {
+			x, y := camera.WorldToScreen(planet.Position)
+			vector.StrokeCircle(target, float32(x), float32(y), 5, 1, mapLootedColor, true)
+		}
+	}
+
+	drawDot(w.Planets[0].Position, 5, mapEarthColor)
+
+	for i, ship := range w.Ships {
+		drawDot(ship.Position, 3, mapShipColor)
+		if i == w.selectedShipIndex {
+			x, y := camera.WorldToScreen(ship.Position)
+			vector.StrokeCircle(target, float32(x), float32(y), 6, 2, mapSelectedColor, true)
+		}
+	}
+}
+
+// planetMapColor returns the color of a planet on the map, matching its sprite in the world view
+func planetMapColor(planet *Planet) color.Color {
+	return palette.Planet(planet.Attributes.Type, planet.Hue)
+}
@@ -2,18 +2,15 @@ package ms2k
 
 import (
 	"fmt"
-	"image"
 	"log"
-	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text"
 
 	"github.com/RemiEven/michelSpace2000/src/ms2k/assets"
 	"github.com/RemiEven/michelSpace2000/src/ms2k/audio"
 	"github.com/RemiEven/michelSpace2000/src/ms2k/rng"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/timing"
 	"github.com/RemiEven/michelSpace2000/src/ms2k/ui"
 )
 
@@ -33,19 +30,18 @@ const (
 	viewportBorderMargin = 32 // should be equal or bigger than half the side length of the biggest sprite to avoid clipping
 )
 
-var (
-	zoomFactor = 1.0
-)
-
 // Game contains all loaded game assets with current game data
 type Game struct {
 	assetLibraryReadyChan <-chan *assets.Library
 	assetLibraryErrChan   <-chan error
+	assetLoadingProgress  *assets.Progress
 	assetLibrary          *assets.Library
 	loadingAssetErr       error
 
 	state int8
 
+	clock *timing.Clock // advanced by a fixed tick each update, its scale slows down or pauses everything in the game
+
 	menu             *MainMenu
 	gameCreationMenu *GameCreationMenu
 
@@ -58,14 +54,20 @@ type Game struct {
 
 // Init initializes a game
 func (g *Game) Init() error {
-	g.assetLibraryReadyChan, g.assetLibraryErrChan = assets.NewAssetLibrary()
-	g.state = stateLoadingAssets
+	g.clock = timing.NewClock()
+	g.loadAssets()
 	return nil
 }
 
+func (g *Game) loadAssets() {
+	g.assetLibraryReadyChan, g.assetLibraryErrChan, g.assetLoadingProgress = assets.NewAssetLibrary()
+	g.loadingAssetErr = nil
+	g.state = stateLoadingAssets
+}
+
 // Update is used to implement the ebiten.Game interface
 func (g *Game) Update() error {
-	timeNow := time.Now()
+	g.clock.Advance(simulationTick)
 
 	nextState := g.state
 	switch g.state {
@@ -88,10 +90,19 @@ func (g *Game) Update() error {
 		default:
 			// do nothing and check again next frame
 		}
+	case stateLoadingAssetsError:
+		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+			g.loadAssets()
+			nextState = g.state
+		}
 	case stateInMenu:
 		nextState = g.menu.Update()
-		if nextState == stateCreatingGame {
+		switch nextState {
+		case stateCreatingGame:
 			g.gameCreationMenu.RandomizeSeed()
+		case stateInCredits:
+			// credits are split in lines again, as text size may have changed in settings
+			g.creditScreen = NewCreditScreen(g.assetLibrary)
 		}
 	case stateCreatingGame:
 		nextState = g.gameCreationMenu.Update()
@@ -101,17 +112,23 @@ func (g *Game) Update() error {
 			if err != nil {
 				log.Fatal(fmt.Errorf("failed to initialize rng: %w", err))
 			}
-			g.World = NewWorld(rng, timeNow, g.assetLibrary)
+			g.World = NewWorld(rng, g.gameCreationMenu.Options, g.clock, g.assetLibrary)
 		}
 	case stateInSettings:
 		nextState = g.settings.Update()
 	case stateInGame:
-		nextState = g.World.Update(timeNow, g.settings)
+		nextState = g.World.Update(g.settings)
 	case stateLost, stateWon:
 		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
 			nextState = stateInMenu
 			g.menu.Reset()
 		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyC) {
+			if code, err := g.World.ShareCode(); err == nil {
+				audio.PlaySound("click")
+				writeClipboard(code)
+			}
+		}
 	case stateInCredits:
 		nextState = g.creditScreen.Update()
 	}
@@ -122,13 +139,11 @@ func (g *Game) Update() error {
 
 // Draw is used to implement the ebiten.Game interface
 func (g *Game) Draw(screen *ebiten.Image) {
-	screenBounds := screen.Bounds()
-
 	switch g.state {
 	case stateLoadingAssets:
-		ebitenutil.DebugPrint(screen, "Loading assets, please wait...")
+		drawLoadingScreen(screen, g.assetLoadingProgress, nil)
 	case stateLoadingAssetsError:
-		ebitenutil.DebugPrint(screen, g.loadingAssetErr.Error())
+		drawLoadingScreen(screen, g.assetLoadingProgress, g.loadingAssetErr)
 	case stateInMenu:
 		g.menu.Draw(screen)
 	case stateInSettings:
@@ -139,38 +154,30 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		g.World.Draw(screen)
 	case stateLost:
 		g.World.Draw(screen)
-		fontFace, _ := g.assetLibrary.FontFaces.Load("oxanium")
-		fontFaceHeight := fontFace.Metrics().Height.Ceil()
-		fontShift := (fontFace.Metrics().Ascent + (fontFace.Metrics().Height-fontFace.Metrics().Ascent-fontFace.Metrics().Descent)/2).Ceil()
-
-		{
-			titleLabel := "Game Over"
-			boundString := text.BoundString(fontFace, titleLabel)
-			ui.DrawBoxAround(screen, g.assetLibrary, (screenBounds.Dx()-boundString.Dx())/2, fontFaceHeight*11, boundString.Dx(), fontFaceHeight, ui.AllBorders)
-			text.Draw(screen, titleLabel, fontFace, (screenBounds.Dx()-boundString.Dx())/2, fontFaceHeight*11+fontShift, ui.TextColor)
-		}
+		g.drawEndTitle(screen, "Game Over")
 	case stateWon:
 		g.World.Draw(screen)
-		fontFace, _ := g.assetLibrary.FontFaces.Load("oxanium")
-		fontFaceHeight := fontFace.Metrics().Height.Ceil()
-		fontShift := (fontFace.Metrics().Ascent + (fontFace.Metrics().Height-fontFace.Metrics().Ascent-fontFace.Metrics().Descent)/2).Ceil()
-
-		{
-			titleLabel := "Victory"
-			boundString := text.BoundString(fontFace, titleLabel)
-			ui.DrawBoxAround(screen, g.assetLibrary, (screenBounds.Dx()-boundString.Dx())/2, fontFaceHeight*11, boundString.Dx(), fontFaceHeight, ui.AllBorders)
-			text.Draw(screen, titleLabel, fontFace, (screenBounds.Dx()-boundString.Dx())/2, fontFaceHeight*11+fontShift, ui.TextColor)
-		}
+		g.drawEndTitle(screen, "Victory")
 	case stateInCredits:
 		g.creditScreen.Draw(screen)
 	}
 }
 
-func translateToDrawPosition(screenBounds *image.Rectangle, gamePosition, viewPortCenter Position, geoM *ebiten.GeoM, zoomFactor float64) {
-	screenWidth, screenHeight := float64(screenBounds.Dx()), float64(screenBounds.Dy())
-	geoM.Translate(-viewPortCenter.X*zoomFactor, -viewPortCenter.Y*zoomFactor)
-	geoM.Translate(screenWidth/2, screenHeight/2)
-	geoM.Translate(gamePosition.X*zoomFactor, gamePosition.Y*zoomFactor)
+func (g *Game) drawEndTitle(screen *ebiten.Image, titleLabel string) {
+	lineHeight := ui.Metrics(g.assetLibrary, ui.BodyText).LineHeight
+	boxWidth := ui.MeasureText(g.assetLibrary, ui.TitleText, titleLabel)
+	ui.DrawTextInBox(screen, g.assetLibrary, ui.TitleText, titleLabel, screen.Bounds().Dx()/2, lineHeight*11, boxWidth, ui.AllBorders, ui.TextColor)
+
+	statisticsLines := g.World.StatisticsLines()
+	statisticsWidth := 0
+	for _, line := range statisticsLines {
+		statisticsWidth = max(statisticsWidth, ui.MeasureText(g.assetLibrary, ui.BodyText, line))
+	}
+	ui.DrawBoxAround(screen, g.assetLibrary, (screen.Bounds().Dx()-statisticsWidth)/2, lineHeight*13, statisticsWidth, lineHeight*len(statisticsLines), ui.AllBorders)
+	for i, line := range statisticsLines {
+		ui.DrawText(screen, g.assetLibrary, ui.BodyText, line, screen.Bounds().Dx()/2, lineHeight*(13+i), ui.AlignCenter, ui.TextColor)
+	}
+	ui.DrawText(screen, g.assetLibrary, ui.HUDText, "Press C to copy the share code", screen.Bounds().Dx()/2, lineHeight*(14+len(statisticsLines)), ui.AlignCenter, ui.TextColor)
 }
 
 // Layout is used to implement the ebiten.Game interface
@@ -1,63 +1,148 @@
 package ms2k
 
 import (
+	"errors"
+	"image/color"
 	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text"
 
 	"github.com/RemiEven/michelSpace2000/src/ms2k/assets"
 	"github.com/RemiEven/michelSpace2000/src/ms2k/audio"
 	"github.com/RemiEven/michelSpace2000/src/ms2k/rng"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/sharecode"
 	"github.com/RemiEven/michelSpace2000/src/ms2k/ui"
 )
 
 const maxSeedLength = 8
 
+const (
+	gameCreationOptionSeed = iota
+	gameCreationOptionDifficulty
+	gameCreationOptionFogOfWar
+	numberOfGameCreationOptions
+)
+
+var errorTextColor = color.RGBA{R: 0xff, G: 0x70, B: 0x70, A: 0xff}
+
 type GameCreationMenu struct {
-	RNG     []rune
-	counter int
+	RNG            []rune // either a seed or a share code, as typed by the player
+	Options        sharecode.Options
+	selectedOption int
+	errorMessage   string
+	clipboardChan  <-chan string
+	counter        int
 
 	assetLibrary *assets.Library
 }
 
 func NewGameCreationMenu(assetLibrary *assets.Library) *GameCreationMenu {
 	return &GameCreationMenu{
+		Options:      sharecode.DefaultOptions,
 		assetLibrary: assetLibrary,
 	}
 }
 
 func (menu *GameCreationMenu) RandomizeSeed() {
-	menu.RNG = []rune(rng.RandomSeed())[:maxSeedLength]
+	menu.RNG = []rune(rng.RandomSeed())
+	menu.errorMessage = ""
 }
 
 // Update updates the game creation menu
 func (menu *GameCreationMenu) Update() int8 {
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) && menu.apply(string(menu.RNG)) {
 		audio.PlaySound("click")
 		return stateInGame
 	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyV) && (ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)) {
+		menu.clipboardChan = readClipboard()
+	}
+	select {
+	case text, ok := <-menu.clipboardChan:
+		menu.clipboardChan = nil
+		if ok && menu.apply(text) {
+			audio.PlaySound("click")
+		}
+	default:
+		// nothing was pasted, or the clipboard is still being read
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		menu.selectedOption = (menu.selectedOption + 1) % numberOfGameCreationOptions
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		menu.selectedOption = (menu.selectedOption + numberOfGameCreationOptions - 1) % numberOfGameCreationOptions
+	}
+
+	optionChanged := inpututil.IsKeyJustPressed(ebiten.KeyRight) || inpututil.IsKeyJustPressed(ebiten.KeyLeft)
+	switch menu.selectedOption {
+	case gameCreationOptionSeed:
+		menu.updateSeed()
+	case gameCreationOptionDifficulty:
+		if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+			menu.Options.Difficulty = menu.Options.Difficulty.Next()
+		} else if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+			menu.Options.Difficulty = menu.Options.Difficulty.Previous()
+		}
+	case gameCreationOptionFogOfWar:
+		if optionChanged {
+			menu.Options.FogOfWar = !menu.Options.FogOfWar
+		}
+	}
+
+	menu.counter++
+	menu.counter %= 60
+	return stateCreatingGame
+}
+
+// updateSeed lets the player type a seed or a share code
+func (menu *GameCreationMenu) updateSeed() {
 	inputChars := ebiten.InputChars()
 	charactersToAdd := make([]rune, 0, len(inputChars))
-	for _, r := range inputChars {
-		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+	for _, r := range strings.ToLower(string(inputChars)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
 			charactersToAdd = append(charactersToAdd, r)
 		}
 	}
 
 	menu.RNG = append(menu.RNG, charactersToAdd...)
-	if len(menu.RNG) > maxSeedLength {
-		menu.RNG = menu.RNG[:maxSeedLength]
+	if len(menu.RNG) > sharecode.MaxLength {
+		menu.RNG = menu.RNG[:sharecode.MaxLength]
 	}
 
 	if menu.repeatingKeyPressed(ebiten.KeyBackspace) && len(menu.RNG) > 0 {
 		menu.RNG = menu.RNG[:len(menu.RNG)-1]
 	}
+	if len(charactersToAdd) > 0 || menu.repeatingKeyPressed(ebiten.KeyBackspace) {
+		menu.errorMessage = ""
+	}
+}
 
-	menu.counter++
-	menu.counter %= 60
-	return stateCreatingGame
+// apply replaces the seed, and the options if it is given a share code. It returns false and shows an error when the input is invalid.
+func (menu *GameCreationMenu) apply(input string) bool {
+	if sharecode.IsShareCode(input) {
+		seed, options, err := sharecode.Decode(input)
+		if err != nil {
+			menu.errorMessage = "Invalid share code"
+			return false
+		}
+		menu.RNG, menu.Options = []rune(seed), options
+	} else {
+		seed := strings.ToLower(strings.TrimSpace(input))
+		if len(seed) > maxSeedLength {
+			menu.errorMessage = "Seeds have at most 8 characters"
+			return false
+		}
+		if _, err := rng.ParseSeed(seed); err != nil && !errors.Is(err, rng.ErrEmptySeed) {
+			menu.errorMessage = "Seeds only have letters and digits"
+			return false
+		}
+		menu.RNG = []rune(seed)
+	}
+	menu.errorMessage = ""
+	return true
 }
 
 func (menu *GameCreationMenu) repeatingKeyPressed(key ebiten.Key) bool {
@@ -77,32 +162,44 @@ func (menu *GameCreationMenu) repeatingKeyPressed(key ebiten.Key) bool {
 
 // Draw draws the game creation menu
 func (menu *GameCreationMenu) Draw(screen *ebiten.Image) {
-	drawSpaceBackground(screen, menu.assetLibrary, Position{})
+	drawSpaceBackground(screen, menu.assetLibrary, Position{}, 1)
 
-	screenWidth := screen.Bounds().Dx()
-
-	fontFace, _ := menu.assetLibrary.FontFaces.Load("oxanium")
-	fontFaceHeight := fontFace.Metrics().Height.Ceil()
-	fontShift := (fontFace.Metrics().Ascent + (fontFace.Metrics().Height-fontFace.Metrics().Ascent-fontFace.Metrics().Descent)/2).Ceil()
+	centerX := screen.Bounds().Dx() / 2
+	lineHeight := ui.Metrics(menu.assetLibrary, ui.BodyText).LineHeight
 
 	baseRNGSeedLabel := "RNG seed: "
-	largestBoundString := text.BoundString(fontFace, baseRNGSeedLabel+strings.Repeat("w", maxSeedLength))
+	rngSeedLabel := baseRNGSeedLabel + string(menu.RNG)
+	boxWidth := max(
+		ui.MeasureText(menu.assetLibrary, ui.BodyText, baseRNGSeedLabel+strings.Repeat("w", maxSeedLength)),
+		ui.MeasureText(menu.assetLibrary, ui.BodyText, rngSeedLabel+"_"),
+	)
 
-	{
-		titleLabel := "Game creation"
-		boundString := text.BoundString(fontFace, titleLabel)
-		ui.DrawBoxAround(screen, menu.assetLibrary, (screenWidth-largestBoundString.Dx())/2, fontFaceHeight*5, largestBoundString.Dx(), fontFaceHeight, ui.AllBorders)
-		text.Draw(screen, titleLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*5+fontShift, ui.TextColor)
+	ui.DrawTextInBox(screen, menu.assetLibrary, ui.TitleText, "Game creation", centerX, lineHeight*5, boxWidth, ui.AllBorders, ui.TextColor)
+
+	color := func(option int) color.Color {
+		if option == menu.selectedOption {
+			return ui.SelectedTextColor
+		}
+		return ui.TextColor
 	}
 
 	{
-		baseRNGSeedLabel := "RNG seed: "
-		rngSeedLabel := baseRNGSeedLabel + string(menu.RNG)
-		if menu.counter < 30 && len(menu.RNG) < maxSeedLength {
+		if menu.counter < 30 && menu.selectedOption == gameCreationOptionSeed && len(menu.RNG) < sharecode.MaxLength {
 			rngSeedLabel += "_"
 		}
-		boundString := text.BoundString(fontFace, baseRNGSeedLabel+strings.Repeat("w", maxSeedLength))
-		ui.DrawBoxAround(screen, menu.assetLibrary, (screenWidth-largestBoundString.Dx())/2, fontFaceHeight*9, largestBoundString.Dx(), fontFaceHeight, ui.AllBorders)
-		text.Draw(screen, rngSeedLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*9+fontShift, ui.TextColor)
+		ui.DrawBoxAround(screen, menu.assetLibrary, centerX-boxWidth/2, lineHeight*9, boxWidth, lineHeight, ui.AllBorders)
+		ui.DrawText(screen, menu.assetLibrary, ui.BodyText, rngSeedLabel, centerX-boxWidth/2, lineHeight*9, ui.AlignLeft, color(gameCreationOptionSeed))
+	}
+
+	fogOfWarLabel := "Off"
+	if menu.Options.FogOfWar {
+		fogOfWarLabel = "On"
+	}
+	ui.DrawTextInBox(screen, menu.assetLibrary, ui.BodyText, "Difficulty: < "+menu.Options.Difficulty.String()+" >", centerX, lineHeight*11, boxWidth, ui.AllBorders, color(gameCreationOptionDifficulty))
+	ui.DrawTextInBox(screen, menu.assetLibrary, ui.BodyText, "Fog of war: < "+fogOfWarLabel+" >", centerX, lineHeight*13, boxWidth, ui.AllBorders, color(gameCreationOptionFogOfWar))
+
+	ui.DrawText(screen, menu.assetLibrary, ui.HUDText, "Type a seed or paste a share code with Ctrl+V", centerX, lineHeight*15, ui.AlignCenter, ui.TextColor)
+	if menu.errorMessage != "" {
+		ui.DrawText(screen, menu.assetLibrary, ui.BodyText, menu.errorMessage, centerX, lineHeight*16, ui.AlignCenter, errorTextColor)
 	}
 }
@@ -0,0 +1,189 @@
+package generation
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+)
+
+// HabitableThreshold is the habitability from which a planet is good enough to become a new home for humans
+const HabitableThreshold = 0.5
+
+// PlanetType is the kind of surface of a planet
+type PlanetType uint8
+
+// Enum of all planet types
+const (
+	RockyPlanet PlanetType = iota
+	OceanPlanet
+	DesertPlanet
+	IcePlanet
+	LavaPlanet
+	GasGiant
+)
+
+// String returns the name of the planet type
+func (planetType PlanetType) String() string {
+	names := [...]string{"rocky", "ocean", "desert", "ice", "lava", "gas giant"}
+	if int(planetType) >= len(names) {
+		return "unknown"
+	}
+	return names[planetType]
+}
+
+// Atmosphere is the kind of air around a planet
+type Atmosphere uint8
+
+// Enum of all atmospheres
+const (
+	NoAtmosphere Atmosphere = iota
+	ThinAtmosphere
+	BreathableAtmosphere
+	ToxicAtmosphere
+	DenseAtmosphere
+)
+
+// String returns the name of the atmosphere
+func (atmosphere Atmosphere) String() string {
+	names := [...]string{"none", "thin", "breathable", "toxic", "dense"}
+	if int(atmosphere) >= len(names) {
+		return "unknown"
+	}
+	return names[atmosphere]
+}
+
+// Hazard is a set of dangers found on a planet
+type Hazard uint8
+
+// Enum of all hazards, which can be combined
+const (
+	RadiationHazard Hazard = 1 << iota
+	StormHazard
+	VolcanismHazard
+	HostileLifeHazard
+
+	NoHazard = Hazard(0)
+)
+
+// Names returns the name of each hazard of the set
+func (hazard Hazard) Names() []string {
+	names := []string{}
+	for i, name := range []string{"radiation", "storms", "volcanism", "hostile life"} {
+		if hazard&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// String returns the names of the hazards of the set
+func (hazard Hazard) String() string {
+	if hazard == NoHazard {
+		return "none"
+	}
+	return strings.Join(hazard.Names(), ", ")
+}
+
+// PlanetAttributes describe the conditions on a planet
+type PlanetAttributes struct {
+	Type        PlanetType
+	Temperature float64 // average surface temperature, in degrees Celsius
+	Atmosphere  Atmosphere
+	Gravity     float64 // relative to the one of Earth
+	Water       float64 // share of the surface covered by water, between 0 and 1
+	Hazards     Hazard
+}
+
+// Habitability returns how suitable a planet is for humans, between 0 and 1
+func (attributes PlanetAttributes) Habitability() float64 {
+	typeFactor := [...]float64{
+		RockyPlanet:  0.9,
+		OceanPlanet:  1,
+		DesertPlanet: 0.5,
+		IcePlanet:    0.4,
+		LavaPlanet:   0.05,
+		GasGiant:     0,
+	}[attributes.Type]
+	atmosphereFactor := [...]float64{
+		NoAtmosphere:         0.05,
+		ThinAtmosphere:       0.5,
+		BreathableAtmosphere: 1,
+		ToxicAtmosphere:      0.15,
+		DenseAtmosphere:      0.4,
+	}[attributes.Atmosphere]
+	temperatureFactor := gaussian(attributes.Temperature, 15, 40)
+	gravityFactor := gaussian(attributes.Gravity, 1, 0.5)
+	waterFactor := 0.3 + 0.7*math.Min(1, attributes.Water/0.5)
+
+	hazardFactor := 1.0
+	for hazard := attributes.Hazards; hazard != 0; hazard &= hazard - 1 {
+		hazardFactor *= 0.7
+	}
+
+	return typeFactor * atmosphereFactor * temperatureFactor * gravityFactor * waterFactor * hazardFactor
+}
+
+// IsHabitable returns whether the planet is habitable enough to be a new home for humans
+func (attributes PlanetAttributes) IsHabitable() bool {
+	return attributes.Habitability() >= HabitableThreshold
+}
+
+func gaussian(value, center, width float64) float64 {
+	deviation := (value - center) / width
+	return math.Exp(-deviation * deviation)
+}
+
+// generateAttributes derives the attributes of a planet from its star, its orbit and its mass
+func generateAttributes(source *rand.Rand, starTemperature, orbitRadius, mass float64) PlanetAttributes {
+	attributes := PlanetAttributes{
+		Temperature: 280*(0.5+starTemperature)*math.Sqrt(firstOrbitRadius/orbitRadius) - 150 + (source.Float64()-0.5)*60,
+		Water:       source.Float64(),
+		Gravity:     mass * (0.8 + 0.4*source.Float64()),
+	}
+
+	atmosphereRoll := source.Float64()
+	switch {
+	case atmosphereRoll < 0.15:
+		attributes.Atmosphere = NoAtmosphere
+	case atmosphereRoll < 0.4:
+		attributes.Atmosphere = ThinAtmosphere
+	case atmosphereRoll < 0.65:
+		attributes.Atmosphere = BreathableAtmosphere
+	case atmosphereRoll < 0.85:
+		attributes.Atmosphere = ToxicAtmosphere
+	default:
+		attributes.Atmosphere = DenseAtmosphere
+	}
+	if attributes.Atmosphere == DenseAtmosphere {
+		attributes.Temperature += 60 // greenhouse effect
+	}
+
+	for _, hazard := range []Hazard{RadiationHazard, StormHazard, VolcanismHazard, HostileLifeHazard} {
+		if source.Float64() < 0.15 {
+			attributes.Hazards |= hazard
+		}
+	}
+
+	switch {
+	case mass > 1.3:
+		attributes.Type = GasGiant
+		attributes.Atmosphere = DenseAtmosphere
+		attributes.Gravity *= 2.5
+		attributes.Water = 0
+		attributes.Hazards |= StormHazard
+	case attributes.Temperature > 250:
+		attributes.Type = LavaPlanet
+		attributes.Water = 0
+		attributes.Hazards |= VolcanismHazard
+	case attributes.Temperature < -60:
+		attributes.Type = IcePlanet
+	case attributes.Water > 0.6:
+		attributes.Type = OceanPlanet
+	case attributes.Water < 0.15:
+		attributes.Type = DesertPlanet
+	default:
+		attributes.Type = RockyPlanet
+	}
+
+	return attributes
+}
@@ -0,0 +1,110 @@
+package generation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/rng"
+)
+
+func TestHabitability(t *testing.T) {
+	earthLike := PlanetAttributes{
+		Type:        OceanPlanet,
+		Temperature: 15,
+		Atmosphere:  BreathableAtmosphere,
+		Gravity:     1,
+		Water:       0.7,
+	}
+	if habitability := earthLike.Habitability(); habitability != 1 {
+		t.Errorf("an Earth-like planet should be perfectly habitable, got [%v]", habitability)
+		return
+	}
+
+	withHazards := earthLike
+	withHazards.Hazards = RadiationHazard | StormHazard
+	if habitability := withHazards.Habitability(); math.Abs(habitability-0.49) > 1e-9 {
+		t.Errorf("unexpected habitability with two hazards: wanted [%v], got [%v]", 0.7*0.7, habitability)
+		return
+	}
+
+	gasGiant := earthLike
+	gasGiant.Type = GasGiant
+	if gasGiant.IsHabitable() {
+		t.Errorf("a gas giant should not be habitable")
+	}
+}
+
+func TestHazardString(t *testing.T) {
+	if s := (StormHazard | HostileLifeHazard).String(); s != "storms, hostile life" {
+		t.Errorf("unexpected hazard names: got [%v]", s)
+	}
+}
+
+// TestHabitablePlanetsAreFindable checks that a game can be won without exploring half of the galaxy
+func TestUnknownAttributeNames(t *testing.T) {
+	if name := PlanetType(42).String(); name != "unknown" {
+		t.Errorf("unexpected name of an unknown planet type: got [%s]", name)
+	}
+	if name := Atmosphere(42).String(); name != "unknown" {
+		t.Errorf("unexpected name of an unknown atmosphere: got [%s]", name)
+	}
+}
+
+func TestAttributesDoNotChangeTheLayout(t *testing.T) {
+	r, err := rng.NewRNG("layout")
+	if err != nil {
+		t.Errorf("failed to create RNG: %v", err)
+		return
+	}
+	chunk := Chunk{X: 2, Y: -1, Density: 1}
+	expected := generateStars(newStreams(r, chunk.X, chunk.Y), chunk, testParameters)
+
+	// drawing other attributes, nicknames and lore must not move anything, so that adding attributes keeps existing galaxies
+	streams := newStreams(r, chunk.X, chunk.Y)
+	streams.attributes, streams.nicknames, streams.lore = rand.New(rand.NewSource(1)), rand.New(rand.NewSource(2)), rand.New(rand.NewSource(3))
+	actual := generateStars(streams, chunk, testParameters)
+
+	if len(actual) != len(expected) {
+		t.Errorf("unexpected number of stars: wanted [%d], got [%d]", len(expected), len(actual))
+		return
+	}
+	for i := range expected {
+		if actual[i].X != expected[i].X || actual[i].Y != expected[i].Y || actual[i].Name != expected[i].Name || len(actual[i].Planets) != len(expected[i].Planets) {
+			t.Errorf("star [%d] changed: wanted [%+v], got [%+v]", i, expected[i], actual[i])
+			return
+		}
+		for j, planet := range expected[i].Planets {
+			other := actual[i].Planets[j]
+			if other.X != planet.X || other.Y != planet.Y || other.Hue != planet.Hue || other.Mass != planet.Mass || len(other.Moons) != len(planet.Moons) {
+				t.Errorf("planet [%s] changed", planet.Name)
+				return
+			}
+		}
+	}
+}
+
+func TestHabitablePlanetsAreFindable(t *testing.T) {
+	for _, seed := range []string{"michel", "2000", "space"} {
+		r, err := rng.NewRNG(seed)
+		if err != nil {
+			t.Errorf("failed to create RNG: %v", err)
+			return
+		}
+		habitablePlanets := 0
+		for x := -4; x <= 4; x++ {
+			for y := -4; y <= 4; y++ {
+				for _, star := range GenerateChunk(r, x, y, testParameters).Stars {
+					for _, planet := range star.Planets {
+						if planet.Attributes.IsHabitable() {
+							habitablePlanets++
+						}
+					}
+				}
+			}
+		}
+		if habitablePlanets == 0 {
+			t.Errorf("no habitable planet around the start with seed [%s]", seed)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+// Package generation procedurally generates the content of the world, one chunk at a time.
+// The content of a chunk only depends on the seed and the coordinates of the chunk.
+//
+// Generation happens in layers: a low frequency noise draws galactic arms that set the density of stars,
+// star systems are placed in the chunk according to that density, then planets are placed on orbits around each star.
+// Worm holes appear in the empty space between arms, and nebulae are laid over everything from another noise.
+package generation
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/naming"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/rng"
+)
+
+const (
+	armNoiseFrequency    = 0.15 // per chunk
+	nebulaNoiseFrequency = 0.45 // per chunk
+
+	maxStarsPerChunk  = 3 // less than naming.MaxStarsPerChunk, so that star designations stay unique
+	minPlanetsPerStar = 1
+	maxPlanetsPerStar = 4
+	firstOrbitRadius  = 110.0
+	orbitSpacing      = 80.0
+	maxOrbitRadius    = firstOrbitRadius + orbitSpacing*(maxPlanetsPerStar-1) + orbitSpacing/6
+	minSystemGap      = 40.0 // minimal distance between the farthest orbits of two star systems
+	// minStarDistance keeps star systems of the same chunk from overlapping
+	minStarDistance = 2*maxOrbitRadius + minSystemGap
+	// starMargin is the minimal distance between a star and the edges of its chunk. It is more than the farthest orbit,
+	// so that star systems of neighbouring chunks never overlap, without having to generate neighbouring chunks.
+	starMargin         = maxOrbitRadius + minSystemGap/2
+	maxMoonsPerPlanet  = 2
+	moonProbability    = 0.35
+	maxWormHoleChance  = 0.6
+	wormHoleStarMargin = 400.0                           // minimal distance between a worm hole and a star
+	wormHoleEdgeMargin = wormHoleStarMargin - starMargin // so that stars of neighbouring chunks are far enough too
+
+	notableHabitability = 0.3 // habitability from which a planet is notable enough to get a nickname
+
+	nebulaThreshold = 0.62
+	minNebulaRadius = 300.0
+	maxNebulaRadius = 700.0
+)
+
+// Parameters holds the dimensions of the world grid
+type Parameters struct {
+	CellSize      float64
+	ChunkSize     int     // number of cells on each side of a chunk
+	HomeClearance float64 // radius around the origin, where Earth is, kept free of other celestial bodies
+}
+
+// Dimensions of the world grid of the game
+const (
+	GameCellSize      = 50
+	GameChunkSize     = 32
+	GameHomeClearance = 200 // so that ships can leave Earth safely
+)
+
+// GameParameters are the parameters the game generates its world with
+var GameParameters = Parameters{
+	CellSize:      GameCellSize,
+	ChunkSize:     GameChunkSize,
+	HomeClearance: GameHomeClearance,
+}
+
+func (p Parameters) chunkWorldSize() float64 {
+	return p.CellSize * float64(p.ChunkSize)
+}
+
+// Chunk is the generated content of a square area of the world
+type Chunk struct {
+	X, Y      int
+	Density   float64 // between 0 and 1, how close the chunk is to the center of a galactic arm
+	Stars     []Star
+	WormHoles []WormHole
+	Nebulae   []Nebula
+}
+
+// Star is the center of a star system
+type Star struct {
+	Name        string
+	X, Y        float64
+	Radius      float64
+	Temperature float64 // between 0 for a cold red star and 1 for a hot blue one
+	Planets     []Planet
+}
+
+// Planet orbits a star
+type Planet struct {
+	Name          string
+	Nickname      string // only given to notable planets
+	Lore          string
+	X, Y          float64
+	Hue           float64
+	RotationSpeed float64 // radians per second
+	Mass          float64
+	Attributes    PlanetAttributes
+	Moons         []Moon
+}
+
+// Moon orbits a planet
+type Moon struct {
+	Phase        float64
+	AngularSpeed float64 // radians per second
+}
+
+// WormHole is a single point of the world
+type WormHole struct {
+	X, Y float64
+}
+
+// NebulaKind tells how a nebula affects ships inside it
+type NebulaKind uint8
+
+// Enum of all nebula kinds
+const (
+	DustNebula NebulaKind = iota // dust blocks sensors
+	IonNebula                    // ionized gas slows ships down
+)
+
+// Nebula is a round region of the world
+type Nebula struct {
+	X, Y   float64
+	Radius float64
+	Kind   NebulaKind
+}
+
+// streams are the random sources used to generate a chunk. Each aspect of the content has its own stream,
+// so that changing how one aspect is generated does not change the others.
+type streams struct {
+	stars      *rand.Rand // number, positions and kinds of stars
+	starNames  *rand.Rand
+	orbits     *rand.Rand // number, positions and motions of planets
+	hues       *rand.Rand
+	attributes *rand.Rand
+	moons      *rand.Rand
+	nicknames  *rand.Rand
+	lore       *rand.Rand
+	wormHoles  *rand.Rand
+	nebulae    *rand.Rand
+}
+
+func newStreams(rng *rng.RNG, x, y int) streams {
+	return streams{
+		stars:      rng.StreamAt("stars", x, y),
+		starNames:  rng.StreamAt("star-names", x, y),
+		orbits:     rng.StreamAt("planet-orbits", x, y),
+		hues:       rng.StreamAt("hues", x, y),
+		attributes: rng.StreamAt("planet-attributes", x, y),
+		moons:      rng.StreamAt("moons", x, y),
+		nicknames:  rng.StreamAt("nicknames", x, y),
+		lore:       rng.StreamAt("lore", x, y),
+		wormHoles:  rng.StreamAt("worm-holes", x, y),
+		nebulae:    rng.StreamAt("nebulae", x, y),
+	}
+}
+
+// GenerateChunk generates the content of the chunk at the given chunk coordinates
+func GenerateChunk(rng *rng.RNG, x, y int, parameters Parameters) Chunk {
+	streams := newStreams(rng, x, y)
+	chunk := Chunk{
+		X:       x,
+		Y:       y,
+		Density: armDensity(rng, x, y),
+	}
+
+	chunk.Stars = generateStars(streams, chunk, parameters)
+	chunk.WormHoles = generateWormHoles(streams.wormHoles, chunk, parameters)
+	chunk.Nebulae = generateNebulae(rng, streams.nebulae, chunk, parameters)
+	clearHome(&chunk, parameters.HomeClearance)
+
+	return chunk
+}
+
+// clearHome removes from the chunk the stars, planets and worm holes that are closer to the origin than the given clearance.
+// It happens after everything is generated, so that the clearance does not change the rest of the chunk.
+func clearHome(chunk *Chunk, clearance float64) {
+	isNearHome := func(x, y float64) bool {
+		return math.Hypot(x, y) < clearance
+	}
+
+	stars := chunk.Stars[:0]
+	for _, star := range chunk.Stars {
+		if isNearHome(star.X, star.Y) {
+			continue
+		}
+		planets := star.Planets[:0]
+		for _, planet := range star.Planets {
+			if !isNearHome(planet.X, planet.Y) {
+				planets = append(planets, planet)
+			}
+		}
+		star.Planets = planets
+		stars = append(stars, star)
+	}
+	chunk.Stars = stars
+
+	wormHoles := chunk.WormHoles[:0]
+	for _, wormHole := range chunk.WormHoles {
+		if !isNearHome(wormHole.X, wormHole.Y) {
+			wormHoles = append(wormHoles, wormHole)
+		}
+	}
+	chunk.WormHoles = wormHoles
+}
+
+// armDensity returns the density of the galactic arm at the given chunk coordinates.
+// It is highest along the ridges of a low frequency noise, which draws long filaments.
+func armDensity(rng *rng.RNG, x, y int) float64 {
+	value := rng.Noise("galactic-arms").ValueAt(float64(x)*armNoiseFrequency, float64(y)*armNoiseFrequency)
+	ridge := 1 - math.Abs(2*value-1)
+	return ridge * ridge
+}
+
+func generateStars(streams streams, chunk Chunk, parameters Parameters) []Star {
+	chunkWorldSize := parameters.chunkWorldSize()
+	source := streams.stars
+	numberOfStars := int(chunk.Density*maxStarsPerChunk + source.Float64())
+
+	stars := []Star{}
+	for attempt := 0; attempt < 4*numberOfStars && len(stars) < numberOfStars; attempt++ {
+		star := Star{
+			X:           float64(chunk.X)*chunkWorldSize + starMargin + source.Float64()*(chunkWorldSize-2*starMargin),
+			Y:           float64(chunk.Y)*chunkWorldSize + starMargin + source.Float64()*(chunkWorldSize-2*starMargin),
+			Radius:      24 + source.Float64()*16,
+			Temperature: source.Float64(),
+		}
+		if isCloserThan(star.X, star.Y, stars, minStarDistance) {
+			continue
+		}
+		star.Name = naming.StarDesignation(streams.starNames, chunk.X, chunk.Y, len(stars))
+		star.Planets = generatePlanets(streams, star)
+		stars = append(stars, star)
+	}
+	return stars
+}
+
+func generatePlanets(streams streams, star Star) []Planet {
+	source := streams.orbits
+	numberOfPlanets := minPlanetsPerStar + source.Intn(maxPlanetsPerStar-minPlanetsPerStar+1)
+	planets := make([]Planet, 0, numberOfPlanets)
+	for i := 0; i < numberOfPlanets; i++ {
+		orbitRadius := firstOrbitRadius + orbitSpacing*float64(i) + (source.Float64()-0.5)*orbitSpacing/3
+		angle := source.Float64() * 2 * math.Pi
+		// a value in [-1, 1], so that bodies can turn both ways
+		motion := 2*source.Float64() - 1
+		planet := Planet{
+			Name:          naming.PlanetDesignation(star.Name, i),
+			X:             star.X + orbitRadius*math.Cos(angle),
+			Y:             star.Y + orbitRadius*math.Sin(angle),
+			Hue:           streams.hues.Float64() * 2 * math.Pi,
+			RotationSpeed: motion * 0.3,
+			Mass:          0.5 + math.Abs(motion),
+		}
+		planet.Attributes = generateAttributes(streams.attributes, star.Temperature, orbitRadius, planet.Mass)
+		for j := 0; j < maxMoonsPerPlanet && streams.moons.Float64() < moonProbability; j++ {
+			moonMotion := 2*streams.moons.Float64() - 1
+			planet.Moons = append(planet.Moons, Moon{
+				Phase:        streams.moons.Float64() * 2 * math.Pi,
+				AngularSpeed: math.Copysign(0.15+0.35*math.Abs(moonMotion), moonMotion),
+			})
+		}
+		if planet.Attributes.Habitability() >= notableHabitability || len(planet.Moons) == maxMoonsPerPlanet {
+			planet.Nickname = naming.Pronounceable(streams.nicknames)
+		}
+		planet.Lore = naming.Lore(streams.lore, naming.Traits{
+			Type:        planet.Attributes.Type.String(),
+			Atmosphere:  planet.Attributes.Atmosphere.String(),
+			Temperature: planet.Attributes.Temperature,
+			Hazards:     planet.Attributes.Hazards.Names(),
+		})
+		planets = append(planets, planet)
+	}
+	return planets
+}
+
+// generateWormHoles places at most one worm hole in the chunk, more likely out of galactic arms
+func generateWormHoles(source *rand.Rand, chunk Chunk, parameters Parameters) []WormHole {
+	chunkWorldSize := parameters.chunkWorldSize()
+	if source.Float64() >= (1-chunk.Density)*maxWormHoleChance {
+		return nil
+	}
+	x := float64(chunk.X)*chunkWorldSize + wormHoleEdgeMargin + source.Float64()*(chunkWorldSize-2*wormHoleEdgeMargin)
+	y := float64(chunk.Y)*chunkWorldSize + wormHoleEdgeMargin + source.Float64()*(chunkWorldSize-2*wormHoleEdgeMargin)
+	if isCloserThan(x, y, chunk.Stars, wormHoleStarMargin) {
+		return nil
+	}
+	return []WormHole{{X: x, Y: y}}
+}
+
+// generateNebulae places a nebula in the chunk where the nebula noise is high enough
+func generateNebulae(rng *rng.RNG, source *rand.Rand, chunk Chunk, parameters Parameters) []Nebula {
+	chunkWorldSize := parameters.chunkWorldSize()
+	value := rng.Noise("nebulae").ValueAt(float64(chunk.X)*nebulaNoiseFrequency, float64(chunk.Y)*nebulaNoiseFrequency)
+	if value < nebulaThreshold {
+		return nil
+	}
+	intensity := (value - nebulaThreshold) / (1 - nebulaThreshold)
+	kind := DustNebula
+	if source.Float64() < 0.5 {
+		kind = IonNebula
+	}
+	return []Nebula{{
+		X:      float64(chunk.X)*chunkWorldSize + (0.25+0.5*source.Float64())*chunkWorldSize,
+		Y:      float64(chunk.Y)*chunkWorldSize + (0.25+0.5*source.Float64())*chunkWorldSize,
+		Radius: minNebulaRadius + intensity*(maxNebulaRadius-minNebulaRadius),
+		Kind:   kind,
+	}}
+}
+
+func isCloserThan(x, y float64, stars []Star, distance float64) bool {
+	for _, star := range stars {
+		if math.Hypot(star.X-x, star.Y-y) < distance {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the name of the nebula kind
+func (kind NebulaKind) String() string {
+	switch kind {
+	case DustNebula:
+		return "dust"
+	case IonNebula:
+		return "ion"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,172 @@
+package generation
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/rng"
+)
+
+var update = flag.Bool("update", false, "update the golden files instead of comparing against them")
+
+var testParameters = Parameters{
+	CellSize:  50,
+	ChunkSize: 32,
+}
+
+// describeChunk returns a text description of a chunk. Numbers are rounded, so that the description
+// does not depend on floating point differences between architectures.
+func describeChunk(chunk Chunk) string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "chunk %v %v density %.3f\n", chunk.X, chunk.Y, chunk.Density)
+	for _, star := range chunk.Stars {
+		fmt.Fprintf(sb, "  star %q at %.1f %.1f radius %.1f temperature %.3f\n", star.Name, star.X, star.Y, star.Radius, star.Temperature)
+		for _, planet := range star.Planets {
+			fmt.Fprintf(sb, "    planet %q at %.1f %.1f hue %.3f rotation %.3f mass %.3f\n", planet.Name, planet.X, planet.Y, planet.Hue, planet.RotationSpeed, planet.Mass)
+			attributes := planet.Attributes
+			fmt.Fprintf(sb, "      %v, %.0f°C, atmosphere %v, gravity %.2f, water %.2f, hazards %v, habitability %.3f\n", attributes.Type, attributes.Temperature, attributes.Atmosphere, attributes.Gravity, attributes.Water, attributes.Hazards, attributes.Habitability())
+			if planet.Nickname != "" {
+				fmt.Fprintf(sb, "      nicknamed %q\n", planet.Nickname)
+			}
+			fmt.Fprintf(sb, "      lore %q\n", planet.Lore)
+			for _, moon := range planet.Moons {
+				fmt.Fprintf(sb, "      moon phase %.3f speed %.3f\n", moon.Phase, moon.AngularSpeed)
+			}
+		}
+	}
+	for _, wormHole := range chunk.WormHoles {
+		fmt.Fprintf(sb, "  worm hole at %.1f %.1f\n", wormHole.X, wormHole.Y)
+	}
+	for _, nebula := range chunk.Nebulae {
+		fmt.Fprintf(sb, "  nebula %v at %.1f %.1f radius %.1f\n", nebula.Kind, nebula.X, nebula.Y, nebula.Radius)
+	}
+	return sb.String()
+}
+
+func describeArea(t *testing.T, seed string, radius int) string {
+	r, err := rng.NewRNG(seed)
+	if err != nil {
+		t.Fatalf("failed to create RNG: %v", err)
+	}
+	sb := &strings.Builder{}
+	for x := -radius; x <= radius; x++ {
+		for y := -radius; y <= radius; y++ {
+			sb.WriteString(describeChunk(GenerateChunk(r, x, y, testParameters)))
+		}
+	}
+	return sb.String()
+}
+
+func TestGoldenChunks(t *testing.T) {
+	for _, seed := range []string{"michel", "2000"} {
+		actual := describeArea(t, seed, 2)
+		goldenPath := filepath.Join("testdata", seed+".golden")
+
+		if *update {
+			if err := os.WriteFile(goldenPath, []byte(actual), 0o644); err != nil {
+				t.Errorf("failed to update golden file [%s]: %v", goldenPath, err)
+			}
+			continue
+		}
+
+		expected, err := os.ReadFile(goldenPath)
+		if err != nil {
+			t.Errorf("failed to read golden file [%s], run the tests with -update to create it: %v", goldenPath, err)
+			continue
+		}
+		if actual != string(expected) {
+			t.Errorf("generated chunks for seed [%s] differ from golden file [%s], run the tests with -update if the change is intended", seed, goldenPath)
+		}
+	}
+}
+
+func TestGenerationDoesNotDependOnOrder(t *testing.T) {
+	r, err := rng.NewRNG("order")
+	if err != nil {
+		t.Errorf("failed to create RNG: %v", err)
+		return
+	}
+	first := describeChunk(GenerateChunk(r, 3, -2, testParameters))
+	GenerateChunk(r, 0, 0, testParameters)
+	GenerateChunk(r, 5, 5, testParameters)
+	if second := describeChunk(GenerateChunk(r, 3, -2, testParameters)); first != second {
+		t.Errorf("generating other chunks changed a chunk:\n%s\n%s", first, second)
+	}
+}
+
+func TestStarsAreApart(t *testing.T) {
+	r, err := rng.NewRNG("stars")
+	if err != nil {
+		t.Errorf("failed to create RNG: %v", err)
+		return
+	}
+	stars, wormHoles := []Star{}, []WormHole{}
+	for x := -5; x <= 5; x++ {
+		for y := -5; y <= 5; y++ {
+			chunk := GenerateChunk(r, x, y, testParameters)
+			stars = append(stars, chunk.Stars...)
+			wormHoles = append(wormHoles, chunk.WormHoles...)
+		}
+	}
+
+	// stars of neighbouring chunks are checked too, as their systems could overlap across the edge
+	for i, star := range stars {
+		if isCloserThan(star.X, star.Y, stars[:i], minStarDistance) {
+			t.Errorf("star [%s] is too close to another star", star.Name)
+			return
+		}
+		for _, other := range stars[:i] {
+			if math.Hypot(star.X-other.X, star.Y-other.Y) < 2*maxOrbitRadius && isInOtherChunk(star, other, testParameters) {
+				t.Errorf("stars [%s] and [%s] of neighbouring chunks have overlapping systems", star.Name, other.Name)
+				return
+			}
+		}
+	}
+	for _, wormHole := range wormHoles {
+		if isCloserThan(wormHole.X, wormHole.Y, stars, wormHoleStarMargin) {
+			t.Errorf("worm hole at [%.0f %.0f] is too close to a star", wormHole.X, wormHole.Y)
+			return
+		}
+	}
+}
+
+func TestOrbitsDoNotIntersect(t *testing.T) {
+	r, err := rng.NewRNG("orbits")
+	if err != nil {
+		t.Errorf("failed to create RNG: %v", err)
+		return
+	}
+	stars := []Star{}
+	for x := -15; x <= 15; x++ {
+		for y := -15; y <= 15; y++ {
+			stars = append(stars, GenerateChunk(r, x, y, testParameters).Stars...)
+		}
+	}
+
+	farthestOrbit := func(star Star) float64 {
+		farthest := 0.0
+		for _, planet := range star.Planets {
+			farthest = max(farthest, math.Hypot(planet.X-star.X, planet.Y-star.Y))
+		}
+		return farthest
+	}
+	for i, star := range stars {
+		for _, other := range stars[:i] {
+			if distance := math.Hypot(star.X-other.X, star.Y-other.Y); distance < farthestOrbit(star)+farthestOrbit(other)+minSystemGap {
+				t.Errorf("orbits of stars [%s] and [%s] intersect, as they are only [%.0f] apart", star.Name, other.Name, distance)
+				return
+			}
+		}
+	}
+}
+
+// isInOtherChunk returns whether the given stars are in different chunks
+func isInOtherChunk(star, other Star, parameters Parameters) bool {
+	size := parameters.chunkWorldSize()
+	return math.Floor(star.X/size) != math.Floor(other.X/size) || math.Floor(star.Y/size) != math.Floor(other.Y/size)
+}
@@ -0,0 +1,128 @@
+package ms2k
+
+import (
+	"time"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/generation"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/physics"
+)
+
+const (
+	shipCollisionRadius = 10.0
+
+	planetRadius          = 20.0 // half the size of the planet sprite as drawn in the world
+	gasGiantRadius        = 28.0
+	earthRadius           = 16.0
+	moonRadius            = 8.0
+	wormHoleRadius        = 16.0
+	planetGravityStrength = 50_000.0 // acceleration at a distance of 1 from a planet of mass 1
+	planetGravityRange    = 250.0
+	// worm holes drag in ships that stop thrusting, but pull them a little less than ships accelerate at contact, so that ships can always escape
+	wormHoleGravityStrength = 400_000.0
+	wormHoleGravityRange    = 400.0
+
+	starGravityStrength = 150_000.0
+	starGravityRange    = 400.0
+
+	ionNebulaSlowdown      = 0.5 // factor applied to the acceleration and max speed of ships in ion nebulae
+	dustNebulaSensorFactor = 0.4 // factor applied to the sensor range of ships in dust nebulae
+
+	starRestitution     = 0.3
+	planetRestitution   = 0.5
+	moonRestitution     = 0.8
+	wormHoleRestitution = 0
+)
+
+// obstacle is a celestial body ships collide with
+type obstacle struct {
+	shape       physics.Circle
+	restitution float64
+}
+
+func (planet *Planet) attractor() physics.Attractor {
+	return physics.Attractor{
+		Position:    physics.Vector(planet.Position),
+		Strength:    planetGravityStrength * planet.Mass,
+		MinDistance: planet.Radius,
+		Range:       planetGravityRange,
+	}
+}
+
+func (wormHole *WormHole) attractor() physics.Attractor {
+	return physics.Attractor{
+		Position:    physics.Vector(wormHole.Position),
+		Strength:    wormHoleGravityStrength,
+		MinDistance: wormHoleRadius,
+		Range:       wormHoleGravityRange,
+	}
+}
+
+func (star *Star) attractor() physics.Attractor {
+	return physics.Attractor{
+		Position:    physics.Vector(star.Position),
+		Strength:    starGravityStrength,
+		MinDistance: star.Radius,
+		Range:       starGravityRange,
+	}
+}
+
+// stepShip moves a ship during a simulation step, pulled by the celestial bodies around and bumping into them
+func (w *World) stepShip(ship *Ship, thrust physics.Vector, elapsed time.Duration) {
+	attractors := []physics.Attractor{}
+	obstacles := []obstacle{}
+	movement := ship.Stats.Movement
+	w.contentAround(ship.Position, func(content *chunkContent) {
+		for _, star := range content.stars {
+			attractors = append(attractors, star.attractor())
+			obstacles = append(obstacles, obstacle{
+				shape:       physics.Circle{Center: physics.Vector(star.Position), Radius: star.Radius},
+				restitution: starRestitution,
+			})
+		}
+		for _, planet := range content.planets {
+			attractors = append(attractors, planet.attractor())
+			obstacles = append(obstacles, obstacle{
+				shape:       physics.Circle{Center: physics.Vector(planet.Position), Radius: planet.Radius},
+				restitution: planetRestitution,
+			})
+			for _, moon := range planet.Moons {
+				obstacles = append(obstacles, obstacle{
+					shape:       physics.Circle{Center: physics.Vector(moon.Position), Radius: moonRadius},
+					restitution: moonRestitution,
+				})
+			}
+		}
+		for _, wormHole := range content.wormHoles {
+			attractors = append(attractors, wormHole.attractor())
+			obstacles = append(obstacles, obstacle{
+				shape:       physics.Circle{Center: physics.Vector(wormHole.Position), Radius: wormHoleRadius},
+				restitution: wormHoleRestitution,
+			})
+		}
+		for _, nebula := range content.nebulae {
+			if nebula.Kind == generation.IonNebula && nebula.Contains(ship.Position) {
+				movement.Acceleration = ship.Stats.Movement.Acceleration * ionNebulaSlowdown
+				movement.MaxSpeed = ship.Stats.Movement.MaxSpeed * ionNebulaSlowdown
+			}
+		}
+	})
+	if w.isInAsteroidField(ship.Position) {
+		movement.Acceleration *= asteroidFieldSlowdown
+		movement.MaxSpeed *= asteroidFieldSlowdown
+	}
+
+	ship.Step(thrust, physics.Gravity(physics.Vector(ship.Position), attractors), movement, obstacles, elapsed)
+}
+
+// sensorRange returns how far a ship currently explores, dust nebulae blocking its sensors
+func (w *World) sensorRange(ship *Ship) float64 {
+	sensorRange := ship.Stats.SensorRange
+	w.contentAround(ship.Position, func(content *chunkContent) {
+		for _, nebula := range content.nebulae {
+			if nebula.Kind == generation.DustNebula && nebula.Contains(ship.Position) {
+				sensorRange = ship.Stats.SensorRange * dustNebulaSensorFactor
+			}
+		}
+	})
+	return sensorRange
+}
@@ -0,0 +1,38 @@
+package ms2k
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/physics"
+)
+
+func TestShipsCanEscapeWormHoles(t *testing.T) {
+	acceleration := defaultShipStats.Movement.Acceleration
+	attractor := (&WormHole{}).attractor()
+	contactDistance := wormHoleRadius + shipCollisionRadius
+
+	if escapeDistance := attractor.EscapeDistance(acceleration); escapeDistance >= contactDistance {
+		t.Errorf("a ship touching a worm hole should be able to escape: the pull beats thrust up to [%.1f], contact is at [%.1f]", escapeDistance, contactDistance)
+		return
+	}
+	if pull := attractor.Pull(physics.Vector{X: contactDistance}).Length(); pull < acceleration/2 {
+		t.Errorf("a worm hole should be hard to escape at contact: got a pull of [%.0f] against an acceleration of [%.0f]", pull, acceleration)
+	}
+}
+
+func TestDockedShipsStayInPlace(t *testing.T) {
+	ship := &Ship{Position: Position{X: 24, Y: 24}, Stats: defaultShipStats, Docked: true}
+	gravity := physics.Vector{X: -50, Y: -50}
+
+	ship.Step(physics.Vector{}, gravity, ship.Stats.Movement, nil, time.Second)
+	if ship.Position != (Position{X: 24, Y: 24}) || !ship.Docked {
+		t.Errorf("a docked ship should not drift: got to [%v]", ship.Position)
+		return
+	}
+
+	ship.Step(physics.Vector{X: 1}, gravity, ship.Stats.Movement, nil, time.Second/60)
+	if ship.Docked || ship.Position == (Position{X: 24, Y: 24}) {
+		t.Errorf("a thrusting ship should leave its dock: got to [%v]", ship.Position)
+	}
+}
@@ -0,0 +1,54 @@
+package ms2k
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/assets"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/ui"
+)
+
+const (
+	loadingBarWidth  = 400
+	loadingBarHeight = 16
+)
+
+var loadingBarErrorColor = color.RGBA{R: 0xcc, G: 0x44, B: 0x44, A: 0xff}
+
+// drawLoadingScreen draws a progress bar of asset loading.
+// Since fonts are assets too, it can only rely on debug printing to display text.
+func drawLoadingScreen(screen *ebiten.Image, progress *assets.Progress, loadingErr error) {
+	screenWidth, screenHeight := screen.Bounds().Dx(), screen.Bounds().Dy()
+	x, y := float32(screenWidth-loadingBarWidth)/2, float32(screenHeight-loadingBarHeight)/2
+
+	loadedAssets, totalAssets := progress.Assets()
+	loadedBytes, expectedBytes := progress.Bytes()
+
+	fillColor := ui.SelectedTextColor
+	if loadingErr != nil {
+		fillColor = loadingBarErrorColor
+	}
+	vector.DrawFilledRect(screen, x, y, float32(float64(loadingBarWidth)*progress.Ratio()), loadingBarHeight, fillColor, false)
+	vector.StrokeRect(screen, x, y, loadingBarWidth, loadingBarHeight, 1, ui.BoxBgColor, false)
+
+	label := fmt.Sprintf("Loading assets, please wait... %v/%v (%s / %s)", loadedAssets, totalAssets, formatByteCount(loadedBytes), formatByteCount(expectedBytes))
+	if loadingErr != nil {
+		label = loadingErr.Error() + "\n\nPress Enter to retry"
+	}
+	ebitenutil.DebugPrintAt(screen, label, int(x), int(y)+2*loadingBarHeight)
+}
+
+func formatByteCount(count int64) string {
+	switch {
+	case count >= 1_000_000:
+		return fmt.Sprintf("%.1f MB", float64(count)/1_000_000)
+	case count >= 1_000:
+		return fmt.Sprintf("%.1f kB", float64(count)/1_000)
+	default:
+		return fmt.Sprintf("%v B", count)
+	}
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text"
 
 	"github.com/RemiEven/michelSpace2000/src/ms2k/assets"
 	"github.com/RemiEven/michelSpace2000/src/ms2k/audio"
@@ -77,23 +76,14 @@ func (menu *MainMenu) Update() int8 {
 
 // Draw draws the MainMenu
 func (menu *MainMenu) Draw(screen *ebiten.Image) {
-	drawSpaceBackground(screen, menu.assetLibrary, Position{})
+	drawSpaceBackground(screen, menu.assetLibrary, Position{}, 1)
 
-	screenWidth := screen.Bounds().Dx()
+	centerX := screen.Bounds().Dx() / 2
+	lineHeight := ui.Metrics(menu.assetLibrary, ui.BodyText).LineHeight
 
-	fontFace, _ := menu.assetLibrary.FontFaces.Load("oxanium")
-	fontFaceHeight := fontFace.Metrics().Height.Ceil()
-	fontShift := (fontFace.Metrics().Ascent + (fontFace.Metrics().Height-fontFace.Metrics().Ascent-fontFace.Metrics().Descent)/2).Ceil()
-
-	longestLabel := "MichelSpace2000"
-	largestBoundString := text.BoundString(fontFace, longestLabel)
-
-	{
-		titleLabel := "MichelSpace2000"
-		boundString := text.BoundString(fontFace, titleLabel)
-		ui.DrawBoxAround(screen, menu.assetLibrary, (screenWidth-largestBoundString.Dx())/2, fontFaceHeight*5, largestBoundString.Dx(), fontFaceHeight, ui.AllBorders)
-		text.Draw(screen, titleLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*5+fontShift, ui.TextColor)
-	}
+	titleLabel := "MichelSpace2000"
+	boxWidth := ui.MeasureText(menu.assetLibrary, ui.TitleText, titleLabel)
+	ui.DrawTextInBox(screen, menu.assetLibrary, ui.TitleText, titleLabel, centerX, lineHeight*5, boxWidth, ui.AllBorders, ui.TextColor)
 
 	color := func(menuOption int8) color.Color {
 		if menuOption == menu.state() {
@@ -102,28 +92,10 @@ func (menu *MainMenu) Draw(screen *ebiten.Image) {
 		return ui.TextColor
 	}
 
-	{
-		newGameLabel := "New game"
-		boundString := text.BoundString(fontFace, newGameLabel)
-		ui.DrawBoxAround(screen, menu.assetLibrary, (screenWidth-largestBoundString.Dx())/2, fontFaceHeight*9, largestBoundString.Dx(), fontFaceHeight, ui.AllBorders)
-		text.Draw(screen, newGameLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*9+fontShift, color(menuStateNewGame))
-	}
-	{
-		settingsLabel := "Controls"
-		boundString := text.BoundString(fontFace, settingsLabel)
-		ui.DrawBoxAround(screen, menu.assetLibrary, (screenWidth-largestBoundString.Dx())/2, fontFaceHeight*11, largestBoundString.Dx(), fontFaceHeight, ui.AllBorders)
-		text.Draw(screen, settingsLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*11+fontShift, color(menuStateSettings))
-	}
-	{
-		creditsLabel := "Credits"
-		boundString := text.BoundString(fontFace, creditsLabel)
-		ui.DrawBoxAround(screen, menu.assetLibrary, (screenWidth-largestBoundString.Dx())/2, fontFaceHeight*13, largestBoundString.Dx(), fontFaceHeight, ui.AllBorders)
-		text.Draw(screen, creditsLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*13+fontShift, color(menuStateCredits))
-	}
+	ui.DrawTextInBox(screen, menu.assetLibrary, ui.BodyText, "New game", centerX, lineHeight*9, boxWidth, ui.AllBorders, color(menuStateNewGame))
+	ui.DrawTextInBox(screen, menu.assetLibrary, ui.BodyText, "Controls", centerX, lineHeight*11, boxWidth, ui.AllBorders, color(menuStateSettings))
+	ui.DrawTextInBox(screen, menu.assetLibrary, ui.BodyText, "Credits", centerX, lineHeight*13, boxWidth, ui.AllBorders, color(menuStateCredits))
 	if len(menu.states) == 4 {
-		exitLabel := "Exit"
-		boundString := text.BoundString(fontFace, exitLabel)
-		ui.DrawBoxAround(screen, menu.assetLibrary, (screenWidth-largestBoundString.Dx())/2, fontFaceHeight*15, largestBoundString.Dx(), fontFaceHeight, ui.AllBorders)
-		text.Draw(screen, exitLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*15+fontShift, color(menuStateExit))
+		ui.DrawTextInBox(screen, menu.assetLibrary, ui.BodyText, "Exit", centerX, lineHeight*15, boxWidth, ui.AllBorders, color(menuStateExit))
 	}
 }
@@ -2,7 +2,8 @@ package ms2k
 
 import (
 	"math"
-	"strconv"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/generation"
 )
 
 func (w *World) ensureChunksAroundAreGenerated(p Position) {
@@ -21,36 +22,96 @@ func (w *World) ensureChunksAroundAreGenerated(p Position) {
 }
 
 func (w *World) generateChunk(x, y int) {
-	for i := 0; i < chunkSize; i += 4 {
-		for j := 0; j < chunkSize; j += 4 {
-			positionShiftNumber := float64(w.rng.GetValueAtPosition(-float32(i+x*chunkSize)*20, -float32(j+y*chunkSize)*20))
-			positionShiftX := int(positionShiftNumber * 4)
-			positionShiftY := int((positionShiftNumber*10 - 1) * 4)
+	chunk := generation.GenerateChunk(w.rng, x, y, generation.GameParameters)
 
-			if value := w.rng.GetValueAtPosition(float32(i+x*chunkSize), float32(j+y*chunkSize)); value >= 0.92 {
-				planet := &Planet{
-					Name: toPlanetName(value),
-					Position: Position{
-						X: cellSize*float64(i+positionShiftX) + float64(x*cellSize*chunkSize),
-						Y: cellSize*float64(j+positionShiftY) + float64(y*cellSize*chunkSize),
-					},
-					Hue: float64(w.rng.GetValueAtPosition(-float32(i+x*chunkSize)/20, -float32(j+y*chunkSize)/20) * 2 * math.Pi),
-				}
+	for _, generatedStar := range chunk.Stars {
+		star := &Star{
+			Name:        generatedStar.Name,
+			Position:    Position{X: generatedStar.X, Y: generatedStar.Y},
+			Radius:      generatedStar.Radius,
+			Temperature: generatedStar.Temperature,
+		}
+		w.Stars = append(w.Stars, star)
+		starContent := w.contentOfChunkContaining(star.Position)
+		starContent.stars = append(starContent.stars, star)
 
-				if value >= 0.96 {
-					planet.AddMoon(float64((value - 0.96) / (1.0 - 0.96) * 4.0 * math.Pi))
-				}
+		for _, generatedPlanet := range generatedStar.Planets {
+			planet := &Planet{
+				Name:          generatedPlanet.Name,
+				Nickname:      generatedPlanet.Nickname,
+				Lore:          generatedPlanet.Lore,
+				Position:      Position{X: generatedPlanet.X, Y: generatedPlanet.Y},
+				Hue:           generatedPlanet.Hue,
+				RotationSpeed: generatedPlanet.RotationSpeed,
+				Mass:          generatedPlanet.Mass,
+				Radius:        planetRadius,
+				Attributes:    generatedPlanet.Attributes,
+			}
+			if planet.Attributes.Type == generation.GasGiant {
+				planet.Radius = gasGiantRadius
+			}
+			for _, moon := range generatedPlanet.Moons {
+				planet.AddMoon(moon.Phase, moon.AngularSpeed)
+			}
+			w.Planets = append(w.Planets, planet)
+			planetContent := w.contentOfChunkContaining(planet.Position)
+			planetContent.planets = append(planetContent.planets, planet)
+		}
+	}
 
-				w.Planets = append(w.Planets, planet)
-			} else if value < 0.02 {
-				wormHole := &WormHole{
-					Position: Position{
-						X: cellSize*float64(i+positionShiftX) + float64(x*cellSize*chunkSize),
-						Y: cellSize*float64(j+positionShiftY) + float64(y*cellSize*chunkSize),
-					},
-				}
+	for _, generatedWormHole := range chunk.WormHoles {
+		wormHole := &WormHole{
+			Position: Position{X: generatedWormHole.X, Y: generatedWormHole.Y},
+		}
+		w.WormHoles = append(w.WormHoles, wormHole)
+		wormHoleContent := w.contentOfChunkContaining(wormHole.Position)
+		wormHoleContent.wormHoles = append(wormHoleContent.wormHoles, wormHole)
+	}
+
+	for _, generatedNebula := range chunk.Nebulae {
+		nebula := &Nebula{
+			Position: Position{X: generatedNebula.X, Y: generatedNebula.Y},
+			Radius:   generatedNebula.Radius,
+			Kind:     generatedNebula.Kind,
+		}
+		w.Nebulae = append(w.Nebulae, nebula)
+		nebulaContent := w.contentOfChunkContaining(nebula.Position)
+		nebulaContent.nebulae = append(nebulaContent.nebulae, nebula)
+	}
+}
 
-				w.WormHoles = append(w.WormHoles, wormHole)
+// chunkContent lists what is located in a chunk. As star systems and nebulae spread,
+// something is not always located in the chunk that generated it.
+type chunkContent struct {
+	stars     []*Star
+	planets   []*Planet
+	wormHoles []*WormHole
+	nebulae   []*Nebula
+}
+
+type chunkCoordinates struct {
+	x, y int
+}
+
+// contentOfChunkContaining returns what is indexed in the chunk containing the given position
+func (w *World) contentOfChunkContaining(p Position) *chunkContent {
+	x, y := getChunkContaining(p)
+	content, ok := w.chunkContents[chunkCoordinates{x: x, y: y}]
+	if !ok {
+		content = &chunkContent{}
+		w.chunkContents[chunkCoordinates{x: x, y: y}] = content
+	}
+	return content
+}
+
+// contentAround calls the given function for the content of the chunk containing the position and of the chunks next to it,
+// which includes at least everything closer than the size of a chunk
+func (w *World) contentAround(p Position, f func(content *chunkContent)) {
+	x0, y0 := getChunkContaining(p)
+	for x := x0 - 1; x <= x0+1; x++ {
+		for y := y0 - 1; y <= y0+1; y++ {
+			if content, ok := w.chunkContents[chunkCoordinates{x: x, y: y}]; ok {
+				f(content)
 			}
 		}
 	}
@@ -59,11 +120,3 @@ func (w *World) generateChunk(x, y int) {
 func getChunkContaining(p Position) (int, int) {
 	return int(math.Floor(p.X / (cellSize * chunkSize))), int(math.Floor(p.Y / (cellSize * chunkSize)))
 }
-
-func toPlanetName(number float32) string {
-	n := int(number * 100_000_000)
-	starCatalogue := [10]string{"GJ", "Kepler", "Corot", "HAT", "HD", "SAO", "FK", "YBS", "HIP", "LP"}[(n/1_000_000)%10]
-	starNumber := (n / 10) % 100_000
-	planetLetter := 'b' + (n % 10)
-	return starCatalogue + " " + strconv.Itoa(starNumber) + " " + string([]rune{rune(planetLetter)})
-}
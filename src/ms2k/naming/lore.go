@@ -0,0 +1,100 @@
+package naming
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// Traits are what the lore of a planet is written from
+type Traits struct {
+	Type        string // the name of the planet type, such as "ocean" or "gas giant"
+	Atmosphere  string // the name of the atmosphere, such as "breathable"
+	Temperature float64
+	Hazards     []string
+}
+
+var (
+	typeLines = map[string][]string{
+		"rocky": {
+			"Grey plains stretch between worn-down mountains.",
+			"Canyons older than Earth scar its crust.",
+			"Its rocky surface is dotted with shallow lakes.",
+		},
+		"ocean": {
+			"A single ocean wraps around the whole planet.",
+			"Scattered archipelagos break the endless waves.",
+			"Deep currents stir a sea as blue as home.",
+		},
+		"desert": {
+			"Dunes the size of mountains drift across it.",
+			"Salt flats glitter under its sky.",
+			"Wind has carved its sandstone into arches.",
+		},
+		"ice": {
+			"Glaciers cover it from pole to pole.",
+			"Liquid water may hide under its frozen crust.",
+			"Geysers of frost erupt along its cracked ice.",
+		},
+		"lava": {
+			"Rivers of molten rock flow across its night side.",
+			"Its crust is a thin skin over a sea of magma.",
+			"Volcanoes light its sky in red.",
+		},
+		"gas giant": {
+			"Bands of clouds race around its swollen body.",
+			"A storm larger than Earth has raged on it for ages.",
+			"Its upper clouds hide a crushing depth of gas.",
+		},
+	}
+	atmosphereLines = map[string]string{
+		"none":       "Without air, its sky is black even at noon.",
+		"thin":       "Its air is too thin to breathe for long.",
+		"breathable": "Its air could be breathed without a mask.",
+		"toxic":      "Its air would poison anyone without a suit.",
+		"dense":      "A heavy atmosphere presses on its surface.",
+	}
+	hazardLines = map[string]string{
+		"radiation":    "Its star bathes it in radiation.",
+		"storms":       "Storms rarely leave it at peace.",
+		"volcanism":    "Earthquakes shake it every day.",
+		"hostile life": "Something living there does not welcome visitors.",
+	}
+	closingLines = []string{
+		"",
+		"Old probes have left no trace of it in the archives.",
+		"Nobody on board has ever seen anything like it.",
+		"Sensors pick up a faint, regular signal from it.",
+		"Its sunsets would be a sight worth the journey.",
+	}
+)
+
+// Lore returns a short flavor text describing a planet with the given traits
+func Lore(source *rand.Rand, traits Traits) string {
+	sentences := []string{}
+	if lines := typeLines[traits.Type]; len(lines) > 0 {
+		sentences = append(sentences, lines[source.Intn(len(lines))])
+	}
+
+	switch {
+	case traits.Temperature > 80:
+		sentences = append(sentences, "Its surface is hot enough to boil water.")
+	case traits.Temperature < -40:
+		sentences = append(sentences, "It is frozen by a bitter cold.")
+	default:
+		if line, ok := atmosphereLines[traits.Atmosphere]; ok {
+			sentences = append(sentences, line)
+		}
+	}
+
+	if len(traits.Hazards) > 0 {
+		if line, ok := hazardLines[traits.Hazards[source.Intn(len(traits.Hazards))]]; ok {
+			sentences = append(sentences, line)
+		}
+	}
+
+	if closing := closingLines[source.Intn(len(closingLines))]; closing != "" {
+		sentences = append(sentences, closing)
+	}
+
+	return strings.Join(sentences, " ")
+}
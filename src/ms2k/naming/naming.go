@@ -0,0 +1,77 @@
+// Package naming procedurally generates the names of celestial bodies and the short texts describing them.
+//
+// Stars get a catalogue designation whose number is derived from the position of their star system,
+// so that two stars never share a designation. Planets are named after their star followed by a letter,
+// in the order of their orbits. Notable worlds may also get a pronounceable nickname.
+package naming
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxStarsPerChunk is the number of stars a chunk can hold without their designations colliding
+	MaxStarsPerChunk = 4
+
+	designationModulus    = 1_000_003 // a prime, so that multiplying by designationMultiplier shuffles each block of numbers
+	designationMultiplier = 740_237
+	designationOffset     = 271_828
+)
+
+var catalogues = [...]string{
+	"GJ", "Kepler", "Corot", "HAT", "HD", "SAO", "FK", "YBS", "HIP", "LP",
+	"TOI", "WASP", "TrES", "XO", "Gliese", "Ross", "Wolf", "LHS", "BD", "Tycho",
+	"OGLE", "KELT", "Qatar", "NGTS", "K2", "TYC", "LTT", "Luyten", "Struve", "Lalande",
+}
+
+// StarDesignation returns the catalogue designation of the star with the given index in the chunk at the given coordinates.
+// The catalogue is picked with the source, while the number only depends on the position of the star system.
+func StarDesignation(source *rand.Rand, chunkX, chunkY, index int) string {
+	return catalogues[source.Intn(len(catalogues))] + " " + strconv.FormatUint(starNumber(chunkX, chunkY, index), 10)
+}
+
+// starNumber returns a different number for each star of each chunk less than 2^30 chunks away from the origin.
+// Numbers are shuffled by blocks, so that stars close to the origin have numbers of at most 6 digits.
+func starNumber(chunkX, chunkY, index int) uint64 {
+	x, y := zigzag(chunkX), zigzag(chunkY)
+	pair := (x+y)*(x+y+1)/2 + y // Cantor pairing function
+	key := pair*MaxStarsPerChunk + uint64(index)
+	block, offset := key/designationModulus, key%designationModulus
+	return block*designationModulus + (offset*designationMultiplier+designationOffset)%designationModulus
+}
+
+// zigzag maps integers to natural numbers: 0, -1, 1, -2, 2... become 0, 1, 2, 3, 4...
+func zigzag(n int) uint64 {
+	if n < 0 {
+		return uint64(-2*n - 1)
+	}
+	return uint64(2 * n)
+}
+
+// PlanetDesignation returns the designation of the planet on the orbit with the given index around a star.
+// As is customary, the letter a is left to the star itself.
+func PlanetDesignation(star string, index int) string {
+	return star + " " + string(rune('b'+index))
+}
+
+var (
+	onsets = [...]string{"", "b", "d", "f", "g", "k", "l", "m", "n", "p", "r", "s", "t", "v", "z", "th", "kr", "tr", "st", "br", "vel", "sh"}
+	vowels = [...]string{"a", "e", "i", "o", "u", "ae", "ia", "io", "ou", "y"}
+	codas  = [...]string{"", "", "", "n", "r", "s", "l", "x", "th", "m"}
+)
+
+// Pronounceable returns a name made of two or three syllables, such as the ones colonists give to notable worlds
+func Pronounceable(source *rand.Rand) string {
+	var name strings.Builder
+	numberOfSyllables := 2 + source.Intn(2)
+	for i := 0; i < numberOfSyllables; i++ {
+		name.WriteString(onsets[source.Intn(len(onsets))])
+		name.WriteString(vowels[source.Intn(len(vowels))])
+		if i == numberOfSyllables-1 || source.Float64() < 0.3 {
+			name.WriteString(codas[source.Intn(len(codas))])
+		}
+	}
+	return strings.ToUpper(name.String()[:1]) + name.String()[1:]
+}
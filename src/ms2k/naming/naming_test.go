@@ -0,0 +1,63 @@
+package naming
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestStarDesignationsAreUnique(t *testing.T) {
+	// stars farther than about 170 chunks from the origin have numbers past the first block
+	const radius = 250
+	chunks := map[uint64][2]int{}
+	for x := -radius; x <= radius; x++ {
+		for y := -radius; y <= radius; y++ {
+			for index := 0; index < MaxStarsPerChunk; index++ {
+				number := starNumber(x, y, index)
+				if other, ok := chunks[number]; ok {
+					t.Errorf("chunks %v and %v share the star number [%d]", other, [2]int{x, y}, number)
+					return
+				}
+				chunks[number] = [2]int{x, y}
+			}
+		}
+	}
+
+	source := rand.New(rand.NewSource(1))
+	designation := StarDesignation(source, 3, -4, 1)
+	if number := designation[strings.LastIndex(designation, " ")+1:]; number != strconv.FormatUint(starNumber(3, -4, 1), 10) {
+		t.Errorf("unexpected number in designation [%s]", designation)
+	}
+}
+
+func TestPlanetDesignation(t *testing.T) {
+	if designation := PlanetDesignation("Kepler 12345", 2); designation != "Kepler 12345 d" {
+		t.Errorf("unexpected planet designation: got [%s]", designation)
+	}
+}
+
+func TestPronounceable(t *testing.T) {
+	name := Pronounceable(rand.New(rand.NewSource(42)))
+	if len(name) < 3 || strings.ToUpper(name[:1]) != name[:1] {
+		t.Errorf("unexpected pronounceable name: got [%s]", name)
+		return
+	}
+	if other := Pronounceable(rand.New(rand.NewSource(42))); other != name {
+		t.Errorf("names should only depend on the seed: got [%s] and [%s]", name, other)
+	}
+}
+
+func TestLore(t *testing.T) {
+	lore := Lore(rand.New(rand.NewSource(7)), Traits{
+		Type:        "lava",
+		Atmosphere:  "toxic",
+		Temperature: 400,
+		Hazards:     []string{"volcanism"},
+	})
+	for _, expected := range []string{"hot enough to boil water", "Earthquakes"} {
+		if !strings.Contains(lore, expected) {
+			t.Errorf("lore [%s] should contain [%s]", lore, expected)
+		}
+	}
+}
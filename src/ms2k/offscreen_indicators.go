@@ -0,0 +1,150 @@
+package ms2k
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/events"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/ui"
+)
+
+const (
+	indicatorScreenMargin = 48 // distance between the indicators and the edges of the screen
+	indicatorArrowSize    = 14
+	indicatorScanRadius   = 20
+)
+
+var indicatorScanBackgroundColor = color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xc0}
+
+// offscreenTarget is something worth pointing at when it is out of the viewport
+type offscreenTarget struct {
+	position     Position
+	color        color.Color
+	scanProgress float64 // percentage of the ongoing scan, if any
+	scanning     bool
+}
+
+// offscreenTargets returns the other ships, Earth, the nearest unscanned planet known to the player and distress beacons
+func (w *World) offscreenTargets() []offscreenTarget {
+	selectedShip := w.getSelectedShip()
+
+	targets := []offscreenTarget{{
+		position: w.Planets[0].Position,
+		color:    mapEarthColor,
+	}}
+
+	for _, ship := range w.Ships {
+		if ship == selectedShip {
+			continue
+		}
+		scanProgress, scanning := ship.scanProgress()
+		targets = append(targets, offscreenTarget{
+			position:     ship.Position,
+			color:        mapShipColor,
+			scanProgress: scanProgress,
+			scanning:     scanning,
+		})
+	}
+
+	var nearestPlanet *Planet
+	distanceToNearestPlanet := math.MaxFloat64
+	for _, planet := range w.Planets[1:] {
+		if planet.Looted || !w.isExplored(planet.Position) {
+			continue
+		}
+		if distance := selectedShip.Position.DistanceTo(&planet.Position); distance < distanceToNearestPlanet {
+			distanceToNearestPlanet = distance
+			nearestPlanet = planet
+		}
+	}
+	if nearestPlanet != nil {
+		targets = append(targets, offscreenTarget{
+			position: nearestPlanet.Position,
+			color:    planetMapColor(nearestPlanet),
+		})
+	}
+
+	for _, event := range w.events.Active() {
+		if event.Kind == events.DistressBeacon {
+			targets = append(targets, offscreenTarget{
+				position: Position{X: event.X, Y: event.Y},
+				color:    beaconColor,
+			})
+		}
+	}
+
+	return targets
+}
+
+// drawOffscreenIndicators draws, on the edges of the screen, arrows pointing to the targets that are out of the viewport
+func (w *World) drawOffscreenIndicators(screen *ebiten.Image) {
+	selectedShip := w.getSelectedShip()
+	screenWidth, screenHeight := float64(screen.Bounds().Dx()), float64(screen.Bounds().Dy())
+	centerX, centerY := screenWidth/2, screenHeight/2
+	halfWidth, halfHeight := centerX-indicatorScreenMargin, centerY-indicatorScreenMargin
+
+	for _, target := range w.offscreenTargets() {
+		if w.camera.IsVisible(target.position, 0) {
+			continue
+		}
+
+		x, y := w.camera.WorldToScreen(target.position)
+		dx, dy := x-centerX, y-centerY
+		// scale the direction down so that the indicator lies on a rectangle inside the screen
+		scale := math.Min(halfWidth/math.Abs(dx), halfHeight/math.Abs(dy))
+		indicatorX, indicatorY := centerX+dx*scale, centerY+dy*scale
+		angle := math.Atan2(dy, dx)
+
+		drawIndicatorArrow(screen, indicatorX, indicatorY, angle, target.color)
+
+		if target.scanning {
+			strokeArc(screen, indicatorX, indicatorY, indicatorScanRadius, 0, 2*math.Pi, 3, indicatorScanBackgroundColor)
+			strokeArc(screen, indicatorX, indicatorY, indicatorScanRadius, -math.Pi/2, -math.Pi/2+2*math.Pi*target.scanProgress/100, 3, ui.SelectedTextColor)
+		}
+
+		distance := selectedShip.Position.DistanceTo(&target.position)
+		labelY := int(indicatorY) - indicatorScanRadius - ui.Metrics(w.assetLibrary, ui.SmallText).LineHeight
+		if dy < 0 {
+			labelY = int(indicatorY) + indicatorScanRadius
+		}
+		ui.DrawText(screen, w.assetLibrary, ui.SmallText, formatDistance(distance), int(indicatorX), labelY, ui.AlignCenter, target.color)
+	}
+}
+
+// drawIndicatorArrow draws a chevron centered on the given point, pointing in the direction of the given angle
+func drawIndicatorArrow(screen *ebiten.Image, x, y, angle float64, clr color.Color) {
+	tipX, tipY := x+math.Cos(angle)*indicatorArrowSize/2, y+math.Sin(angle)*indicatorArrowSize/2
+	for _, side := range []float64{-1, 1} {
+		wingAngle := angle + math.Pi + side*math.Pi/4
+		wingX, wingY := tipX+math.Cos(wingAngle)*indicatorArrowSize, tipY+math.Sin(wingAngle)*indicatorArrowSize
+		vector.StrokeLine(screen, float32(tipX), float32(tipY), float32(wingX), float32(wingY), 3, clr, true)
+	}
+}
+
+// strokeArc draws the part of a circle going clockwise from the start angle to the end angle
+func strokeArc(screen *ebiten.Image, x, y, radius, startAngle, endAngle, strokeWidth float64, clr color.Color) {
+	const segmentsPerTurn = 48
+	numberOfSegments := int(math.Ceil((endAngle - startAngle) / (2 * math.Pi) * segmentsPerTurn))
+	for i := 0; i < numberOfSegments; i++ {
+		from := startAngle + (endAngle-startAngle)*float64(i)/float64(numberOfSegments)
+		to := startAngle + (endAngle-startAngle)*float64(i+1)/float64(numberOfSegments)
+		vector.StrokeLine(
+			screen,
+			float32(x+math.Cos(from)*radius), float32(y+math.Sin(from)*radius),
+			float32(x+math.Cos(to)*radius), float32(y+math.Sin(to)*radius),
+			float32(strokeWidth), clr, true,
+		)
+	}
+}
+
+// formatDistance formats a distance in world units in a short way, such as 850 or 12.3k
+func formatDistance(distance float64) string {
+	if distance < 1000 {
+		return strconv.Itoa(int(distance))
+	}
+	return strconv.FormatFloat(distance/1000, 'f', 1, 64) + "k"
+}
@@ -0,0 +1,97 @@
+// Package palette holds the colors of celestial bodies, so that the game and the galaxy preview draw them alike.
+//
+// Colors are premultiplied by their alpha, as ebiten expects them.
+package palette
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/generation"
+)
+
+// starStops are the colors of the coldest, medium and hottest stars
+var starStops = []color.RGBA{
+	{R: 0xff, G: 0x78, B: 0x50, A: 0xff},
+	{R: 0xff, G: 0xe6, B: 0xa0, A: 0xff},
+	{R: 0xaa, G: 0xc8, B: 0xff, A: 0xff},
+}
+
+// Star returns the color of a star, from red for cold stars to blue for hot ones
+func Star(temperature float64) color.RGBA {
+	position := math.Max(0, math.Min(1, temperature)) * float64(len(starStops)-1)
+	i := min(int(position), len(starStops)-2)
+	progress := position - float64(i)
+	lerp := func(from, to uint8) uint8 {
+		return uint8(float64(from) + (float64(to)-float64(from))*progress)
+	}
+	return color.RGBA{
+		R: lerp(starStops[i].R, starStops[i+1].R),
+		G: lerp(starStops[i].G, starStops[i+1].G),
+		B: lerp(starStops[i].B, starStops[i+1].B),
+		A: 0xff,
+	}
+}
+
+// Nebula returns the translucent color a nebula is drawn with
+func Nebula(kind generation.NebulaKind) color.RGBA {
+	if kind == generation.IonNebula {
+		return color.RGBA{R: 0x10, G: 0x20, B: 0x40, A: 0x40}
+	}
+	return color.RGBA{R: 0x38, G: 0x28, B: 0x18, A: 0x50}
+}
+
+// PlanetHSV returns the hue in radians, saturation and value of a planet, given its type and its own hue.
+// The hue of each planet varies slightly around the one of its type, but gas giants can be of any hue.
+func PlanetHSV(planetType generation.PlanetType, planetHue float64) (hue, saturation, value float64) {
+	typeHue, saturation, value := 0.0, 1.0, 1.0
+	switch planetType {
+	case generation.RockyPlanet:
+		typeHue, saturation, value = math.Pi/6, 0.45, 0.8
+	case generation.OceanPlanet:
+		typeHue = 7 * math.Pi / 6
+	case generation.DesertPlanet:
+		typeHue, saturation = math.Pi/5, 0.9
+	case generation.IcePlanet:
+		typeHue, saturation, value = 7*math.Pi/6, 0.25, 1.2
+	case generation.LavaPlanet:
+		typeHue, value = 0, 0.9
+	case generation.GasGiant:
+		return planetHue, 1, 1
+	}
+	return typeHue + planetHue/8, saturation, value
+}
+
+// Planet returns the color a planet is drawn with when it is too small to show its sprite
+func Planet(planetType generation.PlanetType, planetHue float64) color.RGBA {
+	hue, saturation, value := PlanetHSV(planetType, planetHue)
+	return HSV(hue, 0.6*saturation, min(1, 0.9*value))
+}
+
+// HSV converts a color given by its hue in radians, saturation and value to RGB
+func HSV(hue, saturation, value float64) color.RGBA {
+	hue = math.Mod(hue, 2*math.Pi)
+	if hue < 0 {
+		hue += 2 * math.Pi
+	}
+	sector := hue / (math.Pi / 3)
+	chroma := value * saturation
+	x := chroma * (1 - math.Abs(math.Mod(sector, 2)-1))
+	var r, g, b float64
+	switch int(sector) {
+	case 0:
+		r, g = chroma, x
+	case 1:
+		r, g = x, chroma
+	case 2:
+		g, b = chroma, x
+	case 3:
+		g, b = x, chroma
+	case 4:
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+	m := value - chroma
+	return color.RGBA{R: uint8((r + m) * 0xff), G: uint8((g + m) * 0xff), B: uint8((b + m) * 0xff), A: 0xff}
+}
@@ -0,0 +1,44 @@
+package palette
+
+import (
+	"math"
+	"testing"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/generation"
+)
+
+func TestStarsGoFromRedToBlue(t *testing.T) {
+	cold, hot := Star(0), Star(1)
+	if cold.R <= cold.B || hot.B <= hot.R {
+		t.Errorf("cold stars should be red and hot ones blue: got [%v] and [%v]", cold, hot)
+		return
+	}
+	if Star(-1) != cold || Star(2) != hot {
+		t.Errorf("temperatures out of range should be clamped")
+	}
+}
+
+func TestNebulaeArePremultiplied(t *testing.T) {
+	for _, kind := range []generation.NebulaKind{generation.DustNebula, generation.IonNebula} {
+		if clr := Nebula(kind); clr.R > clr.A || clr.G > clr.A || clr.B > clr.A {
+			t.Errorf("the color of a nebula should be premultiplied by its alpha: got [%v]", clr)
+		}
+	}
+}
+
+func TestHSV(t *testing.T) {
+	for _, testCase := range []struct {
+		hue      float64
+		expected [3]uint8
+	}{
+		{0, [3]uint8{0xff, 0, 0}},
+		{2 * math.Pi / 3, [3]uint8{0, 0xff, 0}},
+		{4 * math.Pi / 3, [3]uint8{0, 0, 0xff}},
+		{-2 * math.Pi / 3, [3]uint8{0, 0, 0xff}},
+	} {
+		clr := HSV(testCase.hue, 1, 1)
+		if got := [3]uint8{clr.R, clr.G, clr.B}; got != testCase.expected {
+			t.Errorf("unexpected color for hue [%v]: wanted [%v], got [%v]", testCase.hue, testCase.expected, got)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Package particles simulates short-lived particles spawned by emitters, for visual effects.
+// It does not depend on the rendering engine and only advances when given the simulation time,
+// so that effects are deterministic.
+package particles
+
+import (
+	"image/color"
+	"math/rand"
+	"time"
+)
+
+// Particle is a point moving in a straight line until its lifetime is over.
+// Its color and scale are interpolated between their start and end values along its life.
+// Colors are not premultiplied, so that a particle fading out keeps its hue.
+type Particle struct {
+	X, Y     float64
+	VX, VY   float64 // velocity, in world units per second
+	Drag     float64 // fraction of the velocity lost per second
+	Age      time.Duration
+	Lifetime time.Duration
+
+	StartColor, EndColor color.NRGBA
+	StartScale, EndScale float64
+
+	alive bool
+}
+
+// Progress returns how far the particle is in its life, between 0 and 1
+func (p *Particle) Progress() float64 {
+	if p.Lifetime <= 0 {
+		return 1
+	}
+	return min(1, float64(p.Age)/float64(p.Lifetime))
+}
+
+// Color returns the current color of the particle
+func (p *Particle) Color() color.NRGBA {
+	progress := p.Progress()
+	lerp := func(from, to uint8) uint8 {
+		return uint8(float64(from) + (float64(to)-float64(from))*progress)
+	}
+	return color.NRGBA{
+		R: lerp(p.StartColor.R, p.EndColor.R),
+		G: lerp(p.StartColor.G, p.EndColor.G),
+		B: lerp(p.StartColor.B, p.EndColor.B),
+		A: lerp(p.StartColor.A, p.EndColor.A),
+	}
+}
+
+// Scale returns the current scale of the particle
+func (p *Particle) Scale() float64 {
+	return p.StartScale + (p.EndScale-p.StartScale)*p.Progress()
+}
+
+// Spawner sets up a new particle, whose position is already set to the one of its emitter.
+// It must only use the given random source so that the system stays deterministic.
+// Emitters give their own source, so that their particles do not depend on which other emitters exist.
+type Spawner func(r *rand.Rand, p *Particle)
+
+// Emitter spawns particles at its position at a given rate while it is active
+type Emitter struct {
+	X, Y   float64
+	Rate   float64 // particles per second
+	Active bool
+
+	spawn   Spawner
+	random  *rand.Rand
+	pending float64 // particles that should have been spawned, but for their fractional part
+}
+
+// System holds a pool of particles and the emitters spawning them
+type System struct {
+	particles []Particle
+	free      []int // indexes of the dead particles in the pool
+	emitters  []*Emitter
+
+	random *rand.Rand
+	now    time.Duration
+}
+
+// NewSystem creates a new particle system that can hold at most the given number of particles.
+// New particles are dropped while the pool is full.
+func NewSystem(seed int64, maxParticles int) *System {
+	free := make([]int, maxParticles)
+	for i := range free {
+		free[i] = maxParticles - 1 - i
+	}
+	return &System{
+		particles: make([]Particle, maxParticles),
+		free:      free,
+		random:    rand.New(rand.NewSource(seed)),
+	}
+}
+
+// NewEmitter adds an active emitter to the system, spawning particles from its own source seeded with the given seed
+func (s *System) NewEmitter(x, y, rate float64, seed int64, spawn Spawner) *Emitter {
+	emitter := &Emitter{
+		X:      x,
+		Y:      y,
+		Rate:   rate,
+		Active: true,
+		spawn:  spawn,
+		random: rand.New(rand.NewSource(seed)),
+	}
+	s.emitters = append(s.emitters, emitter)
+	return emitter
+}
+
+// RemoveEmitter removes an emitter from the system. Particles it already spawned live on.
+func (s *System) RemoveEmitter(emitter *Emitter) {
+	for i, e := range s.emitters {
+		if e == emitter {
+			s.emitters = append(s.emitters[:i], s.emitters[i+1:]...)
+			return
+		}
+	}
+}
+
+// Burst immediately spawns the given number of particles at a position
+func (s *System) Burst(x, y float64, count int, spawn Spawner) {
+	for i := 0; i < count; i++ {
+		s.spawn(x, y, s.random, spawn)
+	}
+}
+
+func (s *System) spawn(x, y float64, random *rand.Rand, spawn Spawner) {
+	if len(s.free) == 0 {
+		return
+	}
+	index := s.free[len(s.free)-1]
+	s.free = s.free[:len(s.free)-1]
+
+	s.particles[index] = Particle{X: x, Y: y}
+	spawn(random, &s.particles[index])
+	s.particles[index].alive = true
+}
+
+// Update advances the system to the given simulation time: particles move and age, and emitters spawn new ones
+func (s *System) Update(simulationTime time.Duration) {
+	elapsed := simulationTime - s.now
+	if elapsed <= 0 {
+		return
+	}
+	s.now = simulationTime
+	elapsedSeconds := elapsed.Seconds()
+
+	for i := range s.particles {
+		p := &s.particles[i]
+		if !p.alive {
+			continue
+		}
+		p.Age += elapsed
+		if p.Age >= p.Lifetime {
+			p.alive = false
+			s.free = append(s.free, i)
+			continue
+		}
+		p.X += p.VX * elapsedSeconds
+		p.Y += p.VY * elapsedSeconds
+		if p.Drag > 0 {
+			damping := max(0, 1-p.Drag*elapsedSeconds)
+			p.VX *= damping
+			p.VY *= damping
+		}
+	}
+
+	for _, emitter := range s.emitters {
+		if !emitter.Active {
+			emitter.pending = 0
+			continue
+		}
+		emitter.pending += emitter.Rate * elapsedSeconds
+		for ; emitter.pending >= 1; emitter.pending-- {
+			s.spawn(emitter.X, emitter.Y, emitter.random, emitter.spawn)
+		}
+	}
+}
+
+// Len returns the number of living particles
+func (s *System) Len() int {
+	return len(s.particles) - len(s.free)
+}
+
+// Range calls f for each living particle
+func (s *System) Range(f func(p *Particle)) {
+	for i := range s.particles {
+		if s.particles[i].alive {
+			f(&s.particles[i])
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package particles
+
+import (
+	"image/color"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func testSpawner(r *rand.Rand, p *Particle) {
+	p.VX, p.VY = r.Float64()*10, r.Float64()*10
+	p.Lifetime = time.Second
+	p.StartColor = color.NRGBA{R: 0xff, A: 0xff}
+	p.StartScale, p.EndScale = 2, 0
+}
+
+func TestEmitterRate(t *testing.T) {
+	s := NewSystem(1, 100)
+	s.NewEmitter(0, 0, 10, 1, testSpawner)
+
+	for tick := 1; tick <= 30; tick++ {
+		s.Update(time.Duration(tick) * time.Second / 60)
+	}
+	if s.Len() != 5 {
+		t.Errorf("unexpected number of particles after half a second: wanted [%v], got [%v]", 5, s.Len())
+	}
+}
+
+func TestParticleLifetime(t *testing.T) {
+	s := NewSystem(1, 100)
+	s.Burst(0, 0, 3, testSpawner)
+
+	s.Update(time.Second / 2)
+	if s.Len() != 3 {
+		t.Errorf("particles should still be alive: wanted [%v], got [%v]", 3, s.Len())
+		return
+	}
+	s.Range(func(p *Particle) {
+		if p.Scale() != 1 {
+			t.Errorf("unexpected scale at half life: wanted [%v], got [%v]", 1, p.Scale())
+		}
+		if p.Color().A != 0x7f {
+			t.Errorf("unexpected alpha at half life: wanted [%v], got [%v]", 0x7f, p.Color().A)
+		}
+	})
+
+	s.Update(time.Second)
+	if s.Len() != 0 {
+		t.Errorf("particles should be dead: got [%v] alive", s.Len())
+	}
+}
+
+func TestPoolIsBounded(t *testing.T) {
+	s := NewSystem(1, 4)
+	s.Burst(0, 0, 10, testSpawner)
+	if s.Len() != 4 {
+		t.Errorf("unexpected number of particles: wanted [%v], got [%v]", 4, s.Len())
+		return
+	}
+
+	s.Update(2 * time.Second)
+	s.Burst(0, 0, 2, testSpawner)
+	if s.Len() != 2 {
+		t.Errorf("dead particles should be reused: wanted [%v], got [%v]", 2, s.Len())
+	}
+}
+
+func TestDeterminism(t *testing.T) {
+	run := func() []Particle {
+		s := NewSystem(42, 100)
+		emitter := s.NewEmitter(0, 0, 30, 7, testSpawner)
+		for tick := 1; tick <= 60; tick++ {
+			emitter.X = float64(tick)
+			s.Update(time.Duration(tick) * time.Second / 60)
+		}
+		particles := []Particle{}
+		s.Range(func(p *Particle) {
+			particles = append(particles, *p)
+		})
+		return particles
+	}
+
+	first, second := run(), run()
+	if len(first) != len(second) {
+		t.Errorf("runs spawned different numbers of particles: [%v] and [%v]", len(first), len(second))
+		return
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("particle %v differs between runs: [%+v] and [%+v]", i, first[i], second[i])
+			return
+		}
+	}
+}
+
+func TestEmittersAreIndependent(t *testing.T) {
+	run := func(withOtherEmitter bool) []Particle {
+		s := NewSystem(42, 1000)
+		if withOtherEmitter {
+			s.NewEmitter(-100, 0, 50, 9, testSpawner)
+		}
+		s.NewEmitter(100, 0, 30, 7, testSpawner)
+		for tick := 1; tick <= 30; tick++ {
+			s.Update(time.Duration(tick) * time.Second / 60)
+		}
+		particles := []Particle{}
+		s.Range(func(p *Particle) {
+			if p.X >= 100 {
+				particles = append(particles, *p)
+			}
+		})
+		return particles
+	}
+
+	alone, withOther := run(false), run(true)
+	if len(alone) == 0 || len(alone) != len(withOther) {
+		t.Errorf("another emitter changed the number of particles of an emitter: [%v] and [%v]", len(alone), len(withOther))
+		return
+	}
+	for _, particle := range alone {
+		found := false
+		for _, other := range withOther {
+			found = found || particle == other
+		}
+		if !found {
+			t.Errorf("another emitter changed particle [%+v]", particle)
+			return
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package physics
+
+// Circle is the shape of a solid celestial body
+type Circle struct {
+	Center Vector
+	Radius float64
+}
+
+// Collide pushes a round body of the given radius out of an obstacle it overlaps, and returns whether it did.
+// The velocity towards the obstacle is reflected and multiplied by the restitution,
+// so that a restitution of 0 stops the body and a restitution of 1 bounces it without losing speed.
+func Collide(body *Body, bodyRadius float64, obstacle Circle, restitution float64) bool {
+	offset := body.Position.Sub(obstacle.Center)
+	distance := offset.Length()
+	minDistance := obstacle.Radius + bodyRadius
+	if distance >= minDistance {
+		return false
+	}
+
+	normal := Vector{X: 0, Y: -1} // arbitrary, for a body exactly at the center of the obstacle
+	if distance > 0 {
+		normal = offset.Scale(1 / distance)
+	}
+	body.Position = obstacle.Center.Add(normal.Scale(minDistance))
+
+	if normalSpeed := body.Velocity.Dot(normal); normalSpeed < 0 {
+		body.Velocity = body.Velocity.Sub(normal.Scale((1 + restitution) * normalSpeed))
+	}
+	return true
+}
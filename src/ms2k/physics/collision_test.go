@@ -0,0 +1,45 @@
+package physics
+
+import "testing"
+
+func TestCollideOutsideObstacle(t *testing.T) {
+	body := &Body{Position: Vector{X: 31}, Velocity: Vector{X: -10}}
+	if Collide(body, 10, Circle{Radius: 20}, 1) {
+		t.Errorf("body should not collide with an obstacle it does not overlap")
+		return
+	}
+	if body.Position != (Vector{X: 31}) || body.Velocity != (Vector{X: -10}) {
+		t.Errorf("body should not be moved, got [%+v]", body)
+	}
+}
+
+func TestCollideStops(t *testing.T) {
+	body := &Body{Position: Vector{X: 25}, Velocity: Vector{X: -10, Y: 5}}
+	if !Collide(body, 10, Circle{Radius: 20}, 0) {
+		t.Errorf("body should collide")
+		return
+	}
+	if body.Position != (Vector{X: 30}) {
+		t.Errorf("body should be pushed out of the obstacle: wanted [%v], got [%v]", Vector{X: 30}, body.Position)
+		return
+	}
+	if body.Velocity != (Vector{Y: 5}) {
+		t.Errorf("only the velocity towards the obstacle should be cancelled: wanted [%v], got [%v]", Vector{Y: 5}, body.Velocity)
+	}
+}
+
+func TestCollideBounces(t *testing.T) {
+	body := &Body{Position: Vector{Y: -25}, Velocity: Vector{Y: 10}}
+	Collide(body, 10, Circle{Radius: 20}, 0.5)
+	if body.Velocity != (Vector{Y: -5}) {
+		t.Errorf("body should bounce back: wanted [%v], got [%v]", Vector{Y: -5}, body.Velocity)
+	}
+}
+
+func TestCollideMovingAway(t *testing.T) {
+	body := &Body{Position: Vector{X: 25}, Velocity: Vector{X: 10}}
+	Collide(body, 10, Circle{Radius: 20}, 1)
+	if body.Velocity != (Vector{X: 10}) {
+		t.Errorf("body moving away should keep its velocity: wanted [%v], got [%v]", Vector{X: 10}, body.Velocity)
+	}
+}
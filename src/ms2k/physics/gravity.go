@@ -0,0 +1,41 @@
+package physics
+
+import "math"
+
+// Attractor pulls bodies towards it, with a strength decreasing with the square of the distance
+type Attractor struct {
+	Position    Vector
+	Strength    float64 // acceleration at a distance of 1, in units per second squared
+	MinDistance float64 // distance under which the pull stops growing, usually the radius of the attractor
+	Range       float64 // distance beyond which the pull is ignored
+}
+
+// Pull returns the acceleration the attractor gives to a body at the given position
+func (a Attractor) Pull(position Vector) Vector {
+	offset := a.Position.Sub(position)
+	distance := offset.Length()
+	if distance == 0 || distance > a.Range {
+		return Vector{}
+	}
+	effectiveDistance := max(distance, a.MinDistance)
+	return offset.Scale(1 / distance).Scale(a.Strength / (effectiveDistance * effectiveDistance))
+}
+
+// EscapeDistance returns the distance under which the pull of the attractor is stronger than the given acceleration,
+// or 0 if the pull is never that strong
+func (a Attractor) EscapeDistance(acceleration float64) float64 {
+	distance := math.Sqrt(a.Strength / acceleration)
+	if distance < a.MinDistance {
+		return 0
+	}
+	return math.Min(distance, a.Range)
+}
+
+// Gravity returns the sum of the accelerations given by attractors to a body at the given position
+func Gravity(position Vector, attractors []Attractor) Vector {
+	acceleration := Vector{}
+	for _, attractor := range attractors {
+		acceleration = acceleration.Add(attractor.Pull(position))
+	}
+	return acceleration
+}
@@ -0,0 +1,61 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAttractorPull(t *testing.T) {
+	attractor := Attractor{
+		Position:    Vector{X: 100, Y: 0},
+		Strength:    10000,
+		MinDistance: 20,
+		Range:       200,
+	}
+
+	for _, testCase := range []struct {
+		position Vector
+		expected Vector
+	}{
+		{position: Vector{X: 0, Y: 0}, expected: Vector{X: 1, Y: 0}},
+		{position: Vector{X: 100, Y: 50}, expected: Vector{X: 0, Y: -4}},
+		{position: Vector{X: 90, Y: 0}, expected: Vector{X: 25, Y: 0}}, // inside the min distance
+		{position: Vector{X: 100, Y: 0}, expected: Vector{}},           // at the center
+		{position: Vector{X: -150, Y: 0}, expected: Vector{}},          // out of range
+	} {
+		if pull := attractor.Pull(testCase.position); pull.Sub(testCase.expected).Length() > 1e-9 {
+			t.Errorf("unexpected pull at [%v]: wanted [%v], got [%v]", testCase.position, testCase.expected, pull)
+		}
+	}
+}
+
+func TestGravitySumsPulls(t *testing.T) {
+	attractors := []Attractor{
+		{Position: Vector{X: -10}, Strength: 100, Range: math.Inf(1)},
+		{Position: Vector{X: 10}, Strength: 100, Range: math.Inf(1)},
+		{Position: Vector{Y: 10}, Strength: 300, Range: math.Inf(1)},
+	}
+	if gravity := Gravity(Vector{}, attractors); gravity.Sub(Vector{Y: 3}).Length() > 1e-9 {
+		t.Errorf("unexpected gravity: wanted [%v], got [%v]", Vector{Y: 3}, gravity)
+	}
+}
+
+func TestAttractorEscapeDistance(t *testing.T) {
+	attractor := Attractor{
+		Strength:    10000,
+		MinDistance: 20,
+		Range:       200,
+	}
+	for _, testCase := range []struct {
+		acceleration float64
+		expected     float64
+	}{
+		{acceleration: 1, expected: 100},
+		{acceleration: 0.1, expected: 200}, // the pull stops at the range
+		{acceleration: 100, expected: 0},   // the pull never grows past the one at the min distance
+	} {
+		if distance := attractor.EscapeDistance(testCase.acceleration); math.Abs(distance-testCase.expected) > 1e-9 {
+			t.Errorf("unexpected escape distance for an acceleration of [%v]: wanted [%v], got [%v]", testCase.acceleration, testCase.expected, distance)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Package physics moves bodies through space. It does not depend on the rendering engine,
+// and bodies only move when stepped by a simulation duration, so that movement is deterministic.
+package physics
+
+import (
+	"math"
+	"time"
+)
+
+// Vector is a position, velocity or force in the plane. The Y axis points south, like on screen.
+type Vector struct {
+	X, Y float64
+}
+
+// Add returns the sum of two vectors
+func (v Vector) Add(other Vector) Vector {
+	return Vector{X: v.X + other.X, Y: v.Y + other.Y}
+}
+
+// Sub returns the difference between two vectors
+func (v Vector) Sub(other Vector) Vector {
+	return Vector{X: v.X - other.X, Y: v.Y - other.Y}
+}
+
+// Scale returns the vector multiplied by a factor
+func (v Vector) Scale(factor float64) Vector {
+	return Vector{X: v.X * factor, Y: v.Y * factor}
+}
+
+// Dot returns the dot product of two vectors
+func (v Vector) Dot(other Vector) float64 {
+	return v.X*other.X + v.Y*other.Y
+}
+
+// Length returns the length of the vector
+func (v Vector) Length() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+
+// Normalized returns a vector of length 1 in the same direction, or the zero vector if the vector is zero
+func (v Vector) Normalized() Vector {
+	length := v.Length()
+	if length == 0 {
+		return Vector{}
+	}
+	return v.Scale(1 / length)
+}
+
+// HeadingVector returns the unit vector pointing towards the given heading
+func HeadingVector(heading float64) Vector {
+	return Vector{X: math.Sin(heading), Y: -math.Cos(heading)}
+}
+
+// HeadingOf returns the heading pointing in the direction of a non-zero vector
+func HeadingOf(v Vector) float64 {
+	return math.Atan2(v.X, -v.Y)
+}
+
+// Tuning holds how a body reacts to thrust
+type Tuning struct {
+	Acceleration float64 // units per second squared at full thrust
+	MaxSpeed     float64 // units per second
+	Drag         float64 // fraction of the velocity lost per second
+	TurnRate     float64 // radians per second
+}
+
+// Body is something moving through space with inertia.
+// Its heading is an angle in radians, 0 pointing north and growing clockwise.
+type Body struct {
+	Position Vector
+	Velocity Vector
+	Heading  float64
+}
+
+// Step moves a body during the given duration. The thrust is the direction the body accelerates towards,
+// and its length, capped to 1, is how hard. The body turns towards the thrust while accelerating.
+func Step(body *Body, thrust Vector, tuning Tuning, elapsed time.Duration) {
+	elapsedSeconds := elapsed.Seconds()
+
+	if thrust.Length() > 1 {
+		thrust = thrust.Normalized()
+	}
+	body.Velocity = body.Velocity.Add(thrust.Scale(tuning.Acceleration * elapsedSeconds))
+	body.Velocity = body.Velocity.Scale(max(0, 1-tuning.Drag*elapsedSeconds))
+	if speed := body.Velocity.Length(); speed > tuning.MaxSpeed {
+		body.Velocity = body.Velocity.Scale(tuning.MaxSpeed / speed)
+	}
+	body.Position = body.Position.Add(body.Velocity.Scale(elapsedSeconds))
+
+	if thrust != (Vector{}) {
+		body.Heading = TurnTowards(body.Heading, HeadingOf(thrust), tuning.TurnRate*elapsedSeconds)
+	}
+}
+
+// TurnTowards returns the heading turned towards a target heading by at most the given angle, taking the shortest way
+func TurnTowards(heading, target, maxAngle float64) float64 {
+	difference := math.Remainder(target-heading, 2*math.Pi)
+	if math.Abs(difference) <= maxAngle {
+		return normalizeAngle(heading + difference)
+	}
+	return normalizeAngle(heading + math.Copysign(maxAngle, difference))
+}
+
+// normalizeAngle returns the same angle, between -π and π
+func normalizeAngle(angle float64) float64 {
+	return math.Remainder(angle, 2*math.Pi)
+}
+
+// Accelerate changes the velocity of a body by applying an acceleration during the given duration
+func Accelerate(body *Body, acceleration Vector, elapsed time.Duration) {
+	body.Velocity = body.Velocity.Add(acceleration.Scale(elapsed.Seconds()))
+}
@@ -0,0 +1,74 @@
+package physics
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+const tick = time.Second / 60
+
+var testTuning = Tuning{
+	Acceleration: 600,
+	MaxSpeed:     180,
+	Drag:         2,
+	TurnRate:     2 * math.Pi,
+}
+
+func TestDiagonalSpeed(t *testing.T) {
+	straight, diagonal := &Body{}, &Body{}
+	for i := 0; i < 120; i++ {
+		Step(straight, Vector{X: 1}, testTuning, tick)
+		Step(diagonal, Vector{X: 1, Y: 1}, testTuning, tick)
+	}
+
+	if straightSpeed, diagonalSpeed := straight.Velocity.Length(), diagonal.Velocity.Length(); math.Abs(straightSpeed-diagonalSpeed) > 1e-9 {
+		t.Errorf("diagonal speed should match straight speed: got [%v] and [%v]", diagonalSpeed, straightSpeed)
+		return
+	}
+	if speed := straight.Velocity.Length(); speed > testTuning.MaxSpeed+1e-9 {
+		t.Errorf("speed should be capped to [%v], got [%v]", testTuning.MaxSpeed, speed)
+	}
+}
+
+func TestDragStopsBody(t *testing.T) {
+	body := &Body{Velocity: Vector{X: 100}}
+	for i := 0; i < 600; i++ {
+		Step(body, Vector{}, testTuning, tick)
+	}
+	if speed := body.Velocity.Length(); speed > 0.01 {
+		t.Errorf("body should have almost stopped, got speed [%v]", speed)
+	}
+}
+
+func TestHeadingTurnsTowardsThrust(t *testing.T) {
+	body := &Body{}
+	Step(body, Vector{X: 1}, testTuning, tick)
+	if expected := 2 * math.Pi / 60; math.Abs(body.Heading-expected) > 1e-6 {
+		t.Errorf("heading should turn at the turn rate: wanted [%v], got [%v]", expected, body.Heading)
+		return
+	}
+
+	for i := 0; i < 60; i++ {
+		Step(body, Vector{X: 1}, testTuning, tick)
+	}
+	if math.Abs(body.Heading-math.Pi/2) > 1e-9 {
+		t.Errorf("heading should point east: wanted [%v], got [%v]", math.Pi/2, body.Heading)
+	}
+}
+
+func TestTurnTowardsTakesShortestWay(t *testing.T) {
+	heading := TurnTowards(-3, 3, 0.1)
+	if heading > -3 && heading < 3 {
+		t.Errorf("turning from -3 to 3 should go through π, got [%v]", heading)
+	}
+}
+
+func TestHeadingVector(t *testing.T) {
+	for _, v := range []Vector{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: -1, Y: 1}} {
+		roundTrip := HeadingVector(HeadingOf(v))
+		if roundTrip.Sub(v.Normalized()).Length() > 1e-9 {
+			t.Errorf("heading of [%v] does not point towards it: got [%v]", v, roundTrip)
+		}
+	}
+}
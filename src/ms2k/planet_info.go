@@ -0,0 +1,132 @@
+package ms2k
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/palette"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/ui"
+)
+
+const (
+	scanRingRadius     = 40.0 // world units between the center of a planet and its innermost scan ring
+	moonScanRingRadius = 14.0 // world units between the center of a moon and its innermost scan ring
+	scanRingSpacing    = 6.0  // screen pixels between the scan rings of different ships
+	scanRingWidth      = 3.0
+
+	planetInfoPanelPadding = 8
+)
+
+// shipScanColors are the colors of the scan rings of each ship, in ship order
+var shipScanColors = []color.Color{
+	ui.SelectedTextColor,
+	color.RGBA{R: 0x50, G: 0xd0, B: 0xff, A: 0xff},
+	color.RGBA{R: 0xff, G: 0x70, B: 0x90, A: 0xff},
+	color.RGBA{R: 0x90, G: 0xff, B: 0x70, A: 0xff},
+}
+
+// drawScanRings draws, around each planet being scanned, one radial progress ring per scanning ship
+func (w *World) drawScanRings(screen *ebiten.Image) {
+	zoom := w.camera.Zoom()
+	for i, ship := range w.Ships {
+		clr := shipScanColors[i%len(shipScanColors)]
+		radius := scanRingRadius*zoom + scanRingSpacing*float64(i)
+		for planet, scan := range ship.PlanetScans {
+			if !w.camera.IsVisible(planet.Position, viewportBorderMargin) {
+				continue
+			}
+			x, y := w.camera.WorldToScreen(planet.Position)
+			strokeArc(screen, x, y, radius, 0, 2*math.Pi, scanRingWidth, indicatorScanBackgroundColor)
+			strokeArc(screen, x, y, radius, -math.Pi/2, -math.Pi/2+2*math.Pi*math.Min(scan.Percentage(), 100)/100, scanRingWidth, clr)
+		}
+		moonRadius := moonScanRingRadius*zoom + scanRingSpacing*float64(i)
+		for moon, scan := range ship.MoonScans {
+			if !w.camera.IsVisible(moon.Position, viewportBorderMargin) {
+				continue
+			}
+			x, y := w.camera.WorldToScreen(moon.Position)
+			strokeArc(screen, x, y, moonRadius, 0, 2*math.Pi, scanRingWidth, indicatorScanBackgroundColor)
+			strokeArc(screen, x, y, moonRadius, -math.Pi/2, -math.Pi/2+2*math.Pi*math.Min(scan.Percentage(), 100)/100, scanRingWidth, clr)
+		}
+	}
+}
+
+// planetInfoLines returns what the player knows about a planet, starting with its name
+func (w *World) planetInfoLines(planet *Planet) []string {
+	if !w.isExplored(planet.Position) {
+		return []string{unknownPlanetName}
+	}
+	if planet == w.Planets[0] {
+		return []string{planet.Name, "Home"}
+	}
+
+	name := planet.Name
+	if planet.Looted {
+		name = planet.DisplayName()
+	}
+	lines := []string{
+		name,
+		"Type: " + planet.Attributes.Type.String(),
+		"Moons: " + strconv.Itoa(len(planet.Moons)),
+	}
+	for i, moon := range planet.Moons {
+		if moon.Looted {
+			lines = append(lines, "Moon "+strconv.Itoa(i+1)+": scanned")
+		}
+	}
+	if planet.Looted {
+		attributes := planet.Attributes
+		lines = append(lines,
+			"Temperature: "+strconv.Itoa(int(math.Round(attributes.Temperature)))+" °C",
+			"Atmosphere: "+attributes.Atmosphere.String(),
+			"Gravity: "+strconv.FormatFloat(attributes.Gravity, 'f', 2, 64)+" g",
+			"Water: "+strconv.Itoa(int(math.Round(100*attributes.Water)))+"%",
+			"Hazards: "+attributes.Hazards.String(),
+			"Habitability: "+formatHabitability(attributes.Habitability()),
+		)
+	} else if scan, ok := w.getSelectedShip().PlanetScans[planet]; ok {
+		lines = append(lines, "Scanning: "+strconv.Itoa(int(math.Min(scan.Percentage(), 100)))+"%")
+	} else {
+		lines = append(lines, "Not scanned")
+	}
+	return lines
+}
+
+// drawPlanetInfoPanel draws the information about a planet in a panel at the bottom of the screen
+func (w *World) drawPlanetInfoPanel(screen *ebiten.Image, planet *Planet) {
+	lines := w.planetInfoLines(planet)
+	titleLineHeight := ui.Metrics(w.assetLibrary, ui.BodyText).LineHeight
+	lineHeight := ui.Metrics(w.assetLibrary, ui.HUDText).LineHeight
+
+	width := ui.MeasureText(w.assetLibrary, ui.BodyText, lines[0])
+	for _, line := range lines[1:] {
+		width = max(width, ui.MeasureText(w.assetLibrary, ui.HUDText, line))
+	}
+	width += 2 * planetInfoPanelPadding
+	height := titleLineHeight + lineHeight*(len(lines)-1)
+
+	screenWidth, screenHeight := screen.Bounds().Dx(), screen.Bounds().Dy()
+	x, y := (screenWidth-width)/2, screenHeight-height
+	ui.DrawBoxAround(screen, w.assetLibrary, x, y, width, height, ui.Left|ui.Top|ui.Right)
+
+	ui.DrawText(screen, w.assetLibrary, ui.BodyText, lines[0], screenWidth/2, y, ui.AlignCenter, ui.TextColor)
+	y += titleLineHeight
+	for _, line := range lines[1:] {
+		ui.DrawText(screen, w.assetLibrary, ui.HUDText, line, x+planetInfoPanelPadding, y, ui.AlignLeft, ui.TextColor)
+		y += lineHeight
+	}
+}
+
+// formatHabitability formats a habitability as a percentage
+func formatHabitability(habitability float64) string {
+	return strconv.Itoa(int(math.Round(100*habitability))) + "%"
+}
+
+// planetAppearance returns how the colors of the planet sprite are changed to match the type of a planet
+func planetAppearance(planet *Planet) (hueShift, saturation, value float64) {
+	hue, saturation, value := palette.PlanetHSV(planet.Attributes.Type, planet.Hue)
+	return hue - planetSpriteHue, saturation, value
+}
@@ -0,0 +1,91 @@
+package preview
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// dump is what is exported as JSON
+type dump struct {
+	Seed       string           `json:"seed"`
+	Area       Area             `json:"area"`
+	Planets    []PlanetRecord   `json:"planets"`
+	WormHoles  []WormHoleRecord `json:"wormHoles"`
+	Statistics Statistics       `json:"statistics"`
+}
+
+// WriteJSON writes the planets, the worm holes and the statistics of the map as JSON
+func (m *Map) WriteJSON(w io.Writer, numberOfNearestPlanets int) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(dump{
+		Seed:       m.Seed,
+		Area:       m.Area,
+		Planets:    m.Planets(),
+		WormHoles:  m.WormHoles(),
+		Statistics: m.Statistics(numberOfNearestPlanets),
+	}); err != nil {
+		return fmt.Errorf("failed to encode map: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes the planets and the worm holes of the map as CSV, one row per celestial body
+func (m *Map) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	rows := [][]string{{"kind", "name", "nickname", "star", "x", "y", "hue", "moons", "type", "habitability"}}
+	for _, planet := range m.Planets() {
+		rows = append(rows, []string{
+			"planet",
+			planet.Name,
+			planet.Nickname,
+			planet.Star,
+			formatFloat(planet.X),
+			formatFloat(planet.Y),
+			formatFloat(planet.Hue),
+			strconv.Itoa(planet.Moons),
+			planet.Type,
+			formatFloat(planet.Habitability),
+		})
+	}
+	for _, wormHole := range m.WormHoles() {
+		rows = append(rows, []string{"worm hole", "", "", "", formatFloat(wormHole.X), formatFloat(wormHole.Y), "", "", "", ""})
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write map as CSV: %w", err)
+	}
+	return nil
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 3, 64)
+}
+
+// WriteReport writes the statistics of the map in a human readable form
+func (m *Map) WriteReport(w io.Writer, numberOfNearestPlanets int) error {
+	statistics := m.Statistics(numberOfNearestPlanets)
+	lines := []string{
+		fmt.Sprintf("Seed %s, chunks %d,%d to %d,%d", m.Seed, m.Area.MinX, m.Area.MinY, m.Area.MaxX, m.Area.MaxY),
+		fmt.Sprintf("%d chunks, %d stars, %d planets (%d habitable), %d worm holes, %d nebulae",
+			statistics.Chunks, statistics.Stars, statistics.Planets, statistics.HabitablePlanets, statistics.WormHoles, statistics.Nebulae),
+		"",
+		"Density per chunk:",
+	}
+	for _, chunk := range statistics.DensityPerChunk {
+		lines = append(lines, fmt.Sprintf("  %4d %4d  density %.2f  %d stars  %d planets", chunk.X, chunk.Y, chunk.Density, chunk.Stars, chunk.Planets))
+	}
+	lines = append(lines, "", "Nearest planets to Earth:")
+	for _, planet := range statistics.NearestPlanets {
+		lines = append(lines, fmt.Sprintf("  %-20s %7.0f away  %-9s habitability %3.0f%%", planet.Name, planet.DistanceToEarth(), planet.Type, 100*planet.Habitability))
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+	return nil
+}
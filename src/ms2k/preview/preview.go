@@ -0,0 +1,155 @@
+// Package preview generates a rectangular area of the world of a seed without running the game,
+// so that it can be reviewed as a picture, exported as data and summed up with statistics.
+package preview
+
+import (
+	"math"
+	"sort"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/generation"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/rng"
+)
+
+// Area is a rectangle of chunks, bounds included
+type Area struct {
+	MinX, MinY, MaxX, MaxY int
+}
+
+// Map is the generated content of an area
+type Map struct {
+	Seed       string
+	Area       Area
+	Parameters generation.Parameters
+	Chunks     []generation.Chunk
+}
+
+// Generate generates every chunk of the area the same way the game does
+func Generate(rng *rng.RNG, area Area, parameters generation.Parameters) *Map {
+	m := &Map{
+		Seed:       rng.Seed(),
+		Area:       area,
+		Parameters: parameters,
+	}
+	for y := area.MinY; y <= area.MaxY; y++ {
+		for x := area.MinX; x <= area.MaxX; x++ {
+			m.Chunks = append(m.Chunks, generation.GenerateChunk(rng, x, y, parameters))
+		}
+	}
+	return m
+}
+
+// PlanetRecord describes a planet of a map
+type PlanetRecord struct {
+	Name         string  `json:"name"`
+	Nickname     string  `json:"nickname,omitempty"`
+	Star         string  `json:"star"`
+	X            float64 `json:"x"`
+	Y            float64 `json:"y"`
+	Hue          float64 `json:"hue"`
+	Moons        int     `json:"moons"`
+	Type         string  `json:"type"`
+	Habitability float64 `json:"habitability"`
+}
+
+// DistanceToEarth returns the distance between the planet and Earth, which is at the origin
+func (planet PlanetRecord) DistanceToEarth() float64 {
+	return math.Hypot(planet.X, planet.Y)
+}
+
+// WormHoleRecord describes a worm hole of a map
+type WormHoleRecord struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Planets returns every planet of the map, chunk by chunk
+func (m *Map) Planets() []PlanetRecord {
+	planets := []PlanetRecord{}
+	for _, chunk := range m.Chunks {
+		for _, star := range chunk.Stars {
+			for _, planet := range star.Planets {
+				planets = append(planets, PlanetRecord{
+					Name:         planet.Name,
+					Nickname:     planet.Nickname,
+					Star:         star.Name,
+					X:            planet.X,
+					Y:            planet.Y,
+					Hue:          planet.Hue,
+					Moons:        len(planet.Moons),
+					Type:         planet.Attributes.Type.String(),
+					Habitability: planet.Attributes.Habitability(),
+				})
+			}
+		}
+	}
+	return planets
+}
+
+// WormHoles returns every worm hole of the map, chunk by chunk
+func (m *Map) WormHoles() []WormHoleRecord {
+	wormHoles := []WormHoleRecord{}
+	for _, chunk := range m.Chunks {
+		for _, wormHole := range chunk.WormHoles {
+			wormHoles = append(wormHoles, WormHoleRecord{X: wormHole.X, Y: wormHole.Y})
+		}
+	}
+	return wormHoles
+}
+
+// ChunkStatistics sums up the content of a chunk
+type ChunkStatistics struct {
+	X       int     `json:"x"`
+	Y       int     `json:"y"`
+	Density float64 `json:"density"`
+	Stars   int     `json:"stars"`
+	Planets int     `json:"planets"`
+}
+
+// Statistics sum up the content of a map
+type Statistics struct {
+	Chunks           int               `json:"chunks"`
+	Stars            int               `json:"stars"`
+	Planets          int               `json:"planets"`
+	HabitablePlanets int               `json:"habitablePlanets"`
+	WormHoles        int               `json:"wormHoles"`
+	Nebulae          int               `json:"nebulae"`
+	DensityPerChunk  []ChunkStatistics `json:"densityPerChunk"`
+	NearestPlanets   []PlanetRecord    `json:"nearestPlanets"` // closest to Earth first
+}
+
+// Statistics returns the statistics of the map, with the given number of planets nearest to Earth
+func (m *Map) Statistics(numberOfNearestPlanets int) Statistics {
+	statistics := Statistics{
+		Chunks:          len(m.Chunks),
+		DensityPerChunk: []ChunkStatistics{},
+	}
+	for _, chunk := range m.Chunks {
+		chunkStatistics := ChunkStatistics{
+			X:       chunk.X,
+			Y:       chunk.Y,
+			Density: chunk.Density,
+			Stars:   len(chunk.Stars),
+		}
+		for _, star := range chunk.Stars {
+			chunkStatistics.Planets += len(star.Planets)
+			for _, planet := range star.Planets {
+				if planet.Attributes.IsHabitable() {
+					statistics.HabitablePlanets++
+				}
+			}
+		}
+		statistics.Stars += chunkStatistics.Stars
+		statistics.Planets += chunkStatistics.Planets
+		statistics.WormHoles += len(chunk.WormHoles)
+		statistics.Nebulae += len(chunk.Nebulae)
+		statistics.DensityPerChunk = append(statistics.DensityPerChunk, chunkStatistics)
+	}
+
+	planets := m.Planets()
+	sort.SliceStable(planets, func(i, j int) bool {
+		return planets[i].DistanceToEarth() < planets[j].DistanceToEarth()
+	})
+	statistics.NearestPlanets = planets[:min(numberOfNearestPlanets, len(planets))]
+
+	return statistics
+}
@@ -0,0 +1,92 @@
+package preview
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/generation"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/rng"
+)
+
+var testArea = Area{MinX: -2, MinY: -1, MaxX: 1, MaxY: 1}
+
+func generateTestMap(t *testing.T) *Map {
+	r, err := rng.NewRNG("michel")
+	if err != nil {
+		t.Fatalf("failed to create RNG: %v", err)
+	}
+	return Generate(r, testArea, generation.GameParameters)
+}
+
+func TestStatistics(t *testing.T) {
+	m := generateTestMap(t)
+	statistics := m.Statistics(5)
+
+	if statistics.Chunks != 12 || len(statistics.DensityPerChunk) != 12 {
+		t.Errorf("unexpected number of chunks: wanted [12], got [%d]", statistics.Chunks)
+		return
+	}
+	if statistics.Planets != len(m.Planets()) {
+		t.Errorf("unexpected number of planets: wanted [%d], got [%d]", len(m.Planets()), statistics.Planets)
+		return
+	}
+	if len(statistics.NearestPlanets) != 5 {
+		t.Errorf("unexpected number of nearest planets: got [%d]", len(statistics.NearestPlanets))
+		return
+	}
+	for i := 1; i < len(statistics.NearestPlanets); i++ {
+		if statistics.NearestPlanets[i-1].DistanceToEarth() > statistics.NearestPlanets[i].DistanceToEarth() {
+			t.Errorf("nearest planets should be sorted by distance to Earth")
+			return
+		}
+	}
+	if nearest := statistics.NearestPlanets[0].DistanceToEarth(); nearest < generation.GameHomeClearance {
+		t.Errorf("planets should be generated out of the home clearance, as in the game: got one at [%v]", nearest)
+	}
+}
+
+func TestExports(t *testing.T) {
+	m := generateTestMap(t)
+
+	jsonOutput := &bytes.Buffer{}
+	if err := m.WriteJSON(jsonOutput, 3); err != nil {
+		t.Errorf("failed to write JSON: %v", err)
+		return
+	}
+	decoded := dump{}
+	if err := json.Unmarshal(jsonOutput.Bytes(), &decoded); err != nil {
+		t.Errorf("failed to decode JSON: %v", err)
+		return
+	}
+	if decoded.Seed != "michel" || len(decoded.Planets) != len(m.Planets()) || len(decoded.WormHoles) != len(m.WormHoles()) {
+		t.Errorf("unexpected JSON content: got seed [%s], [%d] planets and [%d] worm holes", decoded.Seed, len(decoded.Planets), len(decoded.WormHoles))
+		return
+	}
+
+	csvOutput := &bytes.Buffer{}
+	if err := m.WriteCSV(csvOutput); err != nil {
+		t.Errorf("failed to write CSV: %v", err)
+		return
+	}
+	rows, err := csv.NewReader(csvOutput).ReadAll()
+	if err != nil {
+		t.Errorf("failed to read CSV: %v", err)
+		return
+	}
+	if expected := 1 + len(m.Planets()) + len(m.WormHoles()); len(rows) != expected {
+		t.Errorf("unexpected number of CSV rows: wanted [%d], got [%d]", expected, len(rows))
+	}
+}
+
+func TestRender(t *testing.T) {
+	img := generateTestMap(t).Render(64)
+	if bounds := img.Bounds(); bounds.Dx() != 4*64 || bounds.Dy() != 3*64 {
+		t.Errorf("unexpected image size: got [%v]", bounds)
+		return
+	}
+	if earth := img.RGBAAt(2*64, 1*64); earth != earthColor {
+		t.Errorf("Earth should be drawn at the origin: got color [%v]", earth)
+	}
+}
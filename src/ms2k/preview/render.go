@@ -0,0 +1,109 @@
+package preview
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/palette"
+)
+
+var (
+	gridColor      = color.RGBA{R: 0x30, G: 0x30, B: 0x40, A: 0xff}
+	earthColor     = color.RGBA{R: 0x40, G: 0xa0, B: 0xff, A: 0xff}
+	habitableColor = color.RGBA{R: 0x50, G: 0xff, B: 0x70, A: 0xff}
+	wormHoleColor  = color.RGBA{R: 0xc0, G: 0x60, B: 0xff, A: 0xff}
+)
+
+// Render draws the map with the given number of pixels for each side of a chunk.
+// The background of each chunk is brighter where galactic arms are denser.
+func (m *Map) Render(pixelsPerChunk int) *image.RGBA {
+	width := (m.Area.MaxX - m.Area.MinX + 1) * pixelsPerChunk
+	height := (m.Area.MaxY - m.Area.MinY + 1) * pixelsPerChunk
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	scale := float64(pixelsPerChunk) / (m.Parameters.CellSize * float64(m.Parameters.ChunkSize))
+	toImage := func(x, y float64) (float64, float64) {
+		return x*scale - float64(m.Area.MinX*pixelsPerChunk), y*scale - float64(m.Area.MinY*pixelsPerChunk)
+	}
+
+	for _, chunk := range m.Chunks {
+		x0, y0 := (chunk.X-m.Area.MinX)*pixelsPerChunk, (chunk.Y-m.Area.MinY)*pixelsPerChunk
+		background := color.RGBA{R: 0x04, G: 0x04, B: uint8(0x10 + 0x40*chunk.Density), A: 0xff}
+		for y := y0; y < y0+pixelsPerChunk; y++ {
+			for x := x0; x < x0+pixelsPerChunk; x++ {
+				if x == x0 || y == y0 {
+					img.SetRGBA(x, y, gridColor)
+				} else {
+					img.SetRGBA(x, y, background)
+				}
+			}
+		}
+	}
+
+	for _, chunk := range m.Chunks {
+		for _, nebula := range chunk.Nebulae {
+			x, y := toImage(nebula.X, nebula.Y)
+			fillCircle(img, x, y, nebula.Radius*scale, palette.Nebula(nebula.Kind))
+		}
+	}
+
+	for _, chunk := range m.Chunks {
+		for _, wormHole := range chunk.WormHoles {
+			x, y := toImage(wormHole.X, wormHole.Y)
+			strokeCircle(img, x, y, 4, wormHoleColor)
+		}
+		for _, star := range chunk.Stars {
+			x, y := toImage(star.X, star.Y)
+			fillCircle(img, x, y, math.Max(2, star.Radius*scale), palette.Star(star.Temperature))
+			for _, planet := range star.Planets {
+				x, y := toImage(planet.X, planet.Y)
+				fillCircle(img, x, y, 2, palette.Planet(planet.Attributes.Type, planet.Hue))
+				if planet.Attributes.IsHabitable() {
+					strokeCircle(img, x, y, 5, habitableColor)
+				}
+			}
+		}
+	}
+
+	if earthX, earthY := toImage(0, 0); image.Pt(int(earthX), int(earthY)).In(img.Bounds()) {
+		fillCircle(img, earthX, earthY, 3, earthColor)
+		strokeCircle(img, earthX, earthY, 6, color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
+	}
+
+	return img
+}
+
+// fillCircle blends a disk of the given color over the image
+func fillCircle(img *image.RGBA, centerX, centerY, radius float64, clr color.RGBA) {
+	forEachPixel(img, centerX, centerY, radius, func(distance float64) bool {
+		return distance <= radius
+	}, clr)
+}
+
+// strokeCircle blends a one pixel wide circle of the given color over the image
+func strokeCircle(img *image.RGBA, centerX, centerY, radius float64, clr color.RGBA) {
+	forEachPixel(img, centerX, centerY, radius+1, func(distance float64) bool {
+		return math.Abs(distance-radius) <= 0.5
+	}, clr)
+}
+
+func forEachPixel(img *image.RGBA, centerX, centerY, radius float64, isInside func(distance float64) bool, clr color.RGBA) {
+	bounds := img.Bounds().Intersect(image.Rect(int(centerX-radius), int(centerY-radius), int(centerX+radius)+1, int(centerY+radius)+1))
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if isInside(math.Hypot(float64(x)+0.5-centerX, float64(y)+0.5-centerY)) {
+				img.SetRGBA(x, y, blend(img.RGBAAt(x, y), clr))
+			}
+		}
+	}
+}
+
+// blend returns the color of a premultiplied color drawn over an opaque one
+func blend(under, over color.RGBA) color.RGBA {
+	alpha := uint32(over.A)
+	mix := func(a, b uint8) uint8 {
+		return uint8(uint32(a)*(0xff-alpha)/0xff + uint32(b))
+	}
+	return color.RGBA{R: mix(under.R, over.R), G: mix(under.G, over.G), B: mix(under.B, over.B), A: 0xff}
+}
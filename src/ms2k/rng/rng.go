@@ -1,41 +1,43 @@
+// Package rng derives every random value of a game from a single seed.
+//
+// Values are drawn from named streams and noise fields that are independent from each other:
+// drawing more values from one of them, or adding a new one, does not change the values of the others.
+// This way, generating a new attribute does not change the existing worlds of a seed.
 package rng
 
 import (
 	"fmt"
+	"hash/fnv"
 	"math/rand"
-	"time"
 
 	"github.com/ojrac/opensimplex-go"
 )
 
-func init() {
-	rand.Seed(time.Now().Unix())
-}
-
-// RNG can be used to generate random values
+// RNG can be used to generate random values. It is not safe for concurrent use.
 type RNG struct {
-	seed        string
-	source      *rand.Rand
-	noiseSource opensimplex.Noise32
+	seed      string
+	seedValue int64
+	noises    map[string]*Noise
 }
 
-// NewRNG creates a new RNG based on either a given seed or a randomly generated one
+// NewRNG creates a new RNG based on either a given seed or a randomly generated one.
+// The seed of the RNG is the canonical form of the given one.
 func NewRNG(seed string) (*RNG, error) {
-	seedValue := int64(0)
 	if seed == "" {
-		seedValue = rand.Int63()
-		seed = int64ToSeed(seedValue)
-	} else {
-		var err error
-		seedValue, err = seedToInt64(seed)
-		if err != nil {
-			return nil, fmt.Errorf("invalid seed: %w", err)
-		}
+		seed = RandomSeed()
+	}
+	seedValue, err := ParseSeed(seed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed: %w", err)
+	}
+	seed, err = FormatSeed(seedValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed: %w", err)
 	}
 	return &RNG{
-		seed:        seed,
-		source:      rand.New(rand.NewSource(seedValue)),
-		noiseSource: opensimplex.NewNormalized32(seedValue),
+		seed:      seed,
+		seedValue: seedValue,
+		noises:    map[string]*Noise{},
 	}, nil
 }
 
@@ -44,7 +46,53 @@ func (rng *RNG) Seed() string {
 	return rng.seed
 }
 
-// GetValueAtPosition returns a random value for the position as generated by an opensimplex noise source
-func (rng *RNG) GetValueAtPosition(x, y float32) float32 {
-	return rng.noiseSource.Eval2(x, y)
+// Stream returns a new random source for the stream with the given name.
+// Sources of a same stream always draw the same values.
+func (rng *RNG) Stream(name string) *rand.Rand {
+	return rand.New(rand.NewSource(int64(rng.streamHash(name) >> 1)))
+}
+
+// StreamAt returns a new random source for the stream with the given name that also depends on the given coordinates,
+// so that an area of the world is generated the same way whatever the order areas are generated in
+func (rng *RNG) StreamAt(name string, x, y int) *rand.Rand {
+	hash := rng.streamHash(name)
+	for _, coordinate := range []int{x, y} {
+		hash = splitMix64(hash ^ uint64(int64(coordinate)))
+	}
+	return rand.New(rand.NewSource(int64(hash >> 1)))
+}
+
+// Noise returns the noise field with the given name
+func (rng *RNG) Noise(name string) *Noise {
+	noise, ok := rng.noises[name]
+	if !ok {
+		noise = &Noise{source: opensimplex.NewNormalized(int64(rng.streamHash(name) >> 1))}
+		rng.noises[name] = noise
+	}
+	return noise
+}
+
+// streamHash mixes the seed with the name of a stream
+func (rng *RNG) streamHash(name string) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	return splitMix64(uint64(rng.seedValue) ^ hash.Sum64())
+}
+
+// splitMix64 scrambles the bits of a value, so that close values give unrelated results
+func splitMix64(value uint64) uint64 {
+	value += 0x9e3779b97f4a7c15
+	value = (value ^ (value >> 30)) * 0xbf58476d1ce4e5b9
+	value = (value ^ (value >> 27)) * 0x94d049bb133111eb
+	return value ^ (value >> 31)
+}
+
+// Noise is a smooth random field over the plane
+type Noise struct {
+	source opensimplex.Noise
+}
+
+// ValueAt returns the value of the noise at the given position, between 0 and 1
+func (noise *Noise) ValueAt(x, y float64) float64 {
+	return noise.source.Eval2(x, y)
 }
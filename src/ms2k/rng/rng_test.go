@@ -0,0 +1,72 @@
+package rng
+
+import (
+	"testing"
+)
+
+func TestStreamsAreReproducible(t *testing.T) {
+	first, err := NewRNG("michel")
+	if err != nil {
+		t.Errorf("failed to create RNG: %v", err)
+		return
+	}
+	second, err := NewRNG("michel")
+	if err != nil {
+		t.Errorf("failed to create RNG: %v", err)
+		return
+	}
+
+	if a, b := first.Stream("hues").Int63(), second.Stream("hues").Int63(); a != b {
+		t.Errorf("streams with the same name should draw the same values: got [%d] and [%d]", a, b)
+	}
+	if a, b := first.StreamAt("stars", 3, -2).Int63(), second.StreamAt("stars", 3, -2).Int63(); a != b {
+		t.Errorf("streams at the same coordinates should draw the same values: got [%d] and [%d]", a, b)
+	}
+	if a, b := first.Noise("nebulae").ValueAt(1.5, -2.5), second.Noise("nebulae").ValueAt(1.5, -2.5); a != b {
+		t.Errorf("noises with the same name should have the same values: got [%v] and [%v]", a, b)
+	}
+}
+
+func TestStreamsAreIndependent(t *testing.T) {
+	r, err := NewRNG("michel")
+	if err != nil {
+		t.Errorf("failed to create RNG: %v", err)
+		return
+	}
+
+	if r.Stream("hues").Int63() == r.Stream("names").Int63() {
+		t.Errorf("streams with different names should draw different values")
+	}
+	if r.StreamAt("stars", 0, 1).Int63() == r.StreamAt("stars", 1, 0).Int63() {
+		t.Errorf("streams at different coordinates should draw different values")
+	}
+	if r.Noise("galactic-arms").ValueAt(0.5, 0.5) == r.Noise("nebulae").ValueAt(0.5, 0.5) {
+		t.Errorf("noises with different names should have different values")
+	}
+
+	other, err := NewRNG("2000")
+	if err != nil {
+		t.Errorf("failed to create RNG: %v", err)
+		return
+	}
+	if r.Stream("hues").Int63() == other.Stream("hues").Int63() {
+		t.Errorf("streams of different seeds should draw different values")
+	}
+}
+
+func TestNoiseValuesAreNormalized(t *testing.T) {
+	r, err := NewRNG("michel")
+	if err != nil {
+		t.Errorf("failed to create RNG: %v", err)
+		return
+	}
+	noise := r.Noise("galactic-arms")
+	for x := -10.0; x < 10; x += 0.37 {
+		for y := -10.0; y < 10; y += 0.41 {
+			if value := noise.ValueAt(x, y); value < 0 || value > 1 {
+				t.Errorf("noise value at [%v %v] should be between 0 and 1: got [%v]", x, y, value)
+				return
+			}
+		}
+	}
+}
@@ -5,63 +5,86 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
-	"time"
+	"strings"
 )
 
-const radix = '9' - '0' + 'z' - 'a' + 2
+// A seed is the base 36 representation of a non-negative int64, written with digits and lowercase letters.
+// Its canonical form has no leading zeros, except for the seed of 0 which is "0".
+// Parsing then formatting a valid seed gives its canonical form, and formatting then parsing a value gives it back.
 
-func toRadix36(r rune) (int64, error) {
-	if '0' <= r && r <= '9' {
-		return int64(r - 48), nil
-	}
-	if 'a' <= r && r <= 'z' {
-		return int64(r - 'a' + ('9' - '0' + 1)), nil
-	}
-	return 0, errors.New("only number and lowercase characters are supported")
-}
+const (
+	radix = 36
+	// MaxSeedLength is the length of the longest canonical seed, the one of math.MaxInt64
+	MaxSeedLength = 13
+	// randomSeedLength is the maximal length of random seeds, short enough to be typed
+	randomSeedLength = 8
 
-func isValidSeed(seed string) bool {
-	_, err := seedToInt64(seed)
-	return err == nil
-}
+	digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+// Errors returned when a seed cannot be parsed or a value cannot be formatted
+var (
+	ErrEmptySeed        = errors.New("seed is empty")
+	ErrInvalidCharacter = errors.New("only digits and letters are supported")
+	ErrSeedOverflow     = errors.New("seed is too large")
+	ErrNegativeValue    = errors.New("seed values cannot be negative")
+)
 
-func seedToInt64(seed string) (int64, error) {
+// ParseSeed returns the value of a seed, as used to initialize an RNG. Letters may be in any case.
+func ParseSeed(seed string) (int64, error) {
+	if seed == "" {
+		return 0, ErrEmptySeed
+	}
 	result := int64(0)
-	for i, r := range []rune(seed) {
-		v, err := toRadix36(r)
-		if err != nil {
-			return 0, fmt.Errorf("invalid rune at position %v: %w", i, err)
+	for i, r := range seed {
+		value := strings.IndexRune(digits, toLower(r))
+		if value < 0 {
+			return 0, fmt.Errorf("invalid rune [%c] at position %v: %w", r, i, ErrInvalidCharacter)
+		}
+		if result > (math.MaxInt64-int64(value))/radix {
+			return 0, fmt.Errorf("failed to parse seed [%s]: %w", seed, ErrSeedOverflow)
 		}
-		result = radix*result + v
+		result = radix*result + int64(value)
 	}
 	return result, nil
 }
 
-func toRune(value int64) rune {
-	if 0 <= value && value <= '9'-'0' {
-		return '0' + rune(value)
+func toLower(r rune) rune {
+	if 'A' <= r && r <= 'Z' {
+		return r - 'A' + 'a'
+	}
+	return r
+}
+
+// FormatSeed returns the canonical seed that has the given value
+func FormatSeed(value int64) (string, error) {
+	if value < 0 {
+		return "", fmt.Errorf("failed to format seed value [%d]: %w", value, ErrNegativeValue)
+	}
+	if value == 0 {
+		return "0", nil
+	}
+	seed := make([]byte, 0, MaxSeedLength)
+	for ; value > 0; value /= radix {
+		seed = append(seed, digits[value%radix])
 	}
-	if '9'-'0'+1 <= value && value <= radix-1 {
-		return 'a' - ('9' - '0' + 1) + rune(value)
+	for i, j := 0, len(seed)-1; i < j; i, j = i+1, j-1 {
+		seed[i], seed[j] = seed[j], seed[i]
 	}
-	return -1
+	return string(seed), nil
 }
 
-func int64ToSeed(value int64) string {
-	e := int(math.Floor(math.Log(float64(value)) / math.Log(radix)))
-	seed := make([]rune, 0, e)
-	posValue := int64(math.Pow(radix, float64(e)))
-	for e >= 0 {
-		digit := value / posValue
-		seed = append(seed, toRune(digit))
-		value %= posValue
-		posValue /= radix
-		e--
+// CanonicalSeed returns the canonical form of a seed
+func CanonicalSeed(seed string) (string, error) {
+	value, err := ParseSeed(seed)
+	if err != nil {
+		return "", err
 	}
-	return string(seed)
+	return FormatSeed(value)
 }
 
-// RandomSeed generates a random seed
+// RandomSeed generates a random canonical seed, short enough to be typed by players
 func RandomSeed() string {
-	return int64ToSeed(rand.NewSource(time.Now().Unix()).Int63())
+	seed, _ := FormatSeed(rand.Int63n(int64(math.Pow(radix, randomSeedLength))))
+	return seed
 }
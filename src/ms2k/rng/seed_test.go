@@ -0,0 +1,137 @@
+package rng
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"testing/quick"
+)
+
+func TestParseSeed(t *testing.T) {
+	for seed, expected := range map[string]int64{
+		"0":             0,
+		"z":             35,
+		"10":            36,
+		"Michel":        1_361_071_389,
+		"007":           7,
+		"1y2p0ij32e8e7": math.MaxInt64,
+	} {
+		value, err := ParseSeed(seed)
+		if err != nil {
+			t.Errorf("failed to parse seed [%s]: %v", seed, err)
+			return
+		}
+		if value != expected {
+			t.Errorf("unexpected value of seed [%s]: wanted [%d], got [%d]", seed, expected, value)
+			return
+		}
+	}
+}
+
+func TestParseSeedErrors(t *testing.T) {
+	for seed, expected := range map[string]error{
+		"":              ErrEmptySeed,
+		"mi-chel":       ErrInvalidCharacter,
+		"é":             ErrInvalidCharacter,
+		"1y2p0ij32e8e8": ErrSeedOverflow,
+		"zzzzzzzzzzzzz": ErrSeedOverflow,
+	} {
+		if _, err := ParseSeed(seed); !errors.Is(err, expected) {
+			t.Errorf("unexpected error when parsing seed [%s]: wanted [%v], got [%v]", seed, expected, err)
+			return
+		}
+	}
+}
+
+func TestFormatSeedRejectsNegativeValues(t *testing.T) {
+	if _, err := FormatSeed(-1); !errors.Is(err, ErrNegativeValue) {
+		t.Errorf("unexpected error when formatting a negative value: got [%v]", err)
+	}
+}
+
+func TestFormatThenParseRoundTrips(t *testing.T) {
+	roundTrips := func(value int64) bool {
+		if value < 0 {
+			value = -(value + 1)
+		}
+		seed, err := FormatSeed(value)
+		if err != nil || len(seed) > MaxSeedLength {
+			return false
+		}
+		parsed, err := ParseSeed(seed)
+		return err == nil && parsed == value
+	}
+	if err := quick.Check(roundTrips, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRandomSeedsAreShortAndCanonical(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		seed := RandomSeed()
+		if len(seed) > randomSeedLength {
+			t.Errorf("random seed [%s] is longer than [%d]", seed, randomSeedLength)
+			return
+		}
+		if canonical, err := CanonicalSeed(seed); err != nil || canonical != seed {
+			t.Errorf("random seed [%s] is not canonical: got [%s], %v", seed, canonical, err)
+			return
+		}
+	}
+}
+
+func FuzzParseSeed(f *testing.F) {
+	for _, seed := range []string{"", "0", "michel", "MICHEL", "00abc", "1y2p0ij32e8e7", "1y2p0ij32e8e8", "a-b"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed string) {
+		value, err := ParseSeed(seed)
+		if err != nil {
+			return
+		}
+		if value < 0 {
+			t.Errorf("seed [%s] has negative value [%d]", seed, value)
+			return
+		}
+		canonical, err := FormatSeed(value)
+		if err != nil {
+			t.Errorf("failed to format value [%d] of seed [%s]: %v", value, seed, err)
+			return
+		}
+		if again, err := ParseSeed(canonical); err != nil || again != value {
+			t.Errorf("canonical seed [%s] of seed [%s] does not have the same value: got [%d], %v", canonical, seed, again, err)
+		}
+		if again, _ := CanonicalSeed(canonical); again != canonical {
+			t.Errorf("canonical form of seed [%s] is not stable: got [%s]", canonical, again)
+		}
+	})
+}
+
+func FuzzFormatSeed(f *testing.F) {
+	for _, value := range []int64{0, 1, 35, 36, math.MaxInt64, -1, math.MinInt64} {
+		f.Add(value)
+	}
+	f.Fuzz(func(t *testing.T, value int64) {
+		seed, err := FormatSeed(value)
+		if value < 0 {
+			if !errors.Is(err, ErrNegativeValue) {
+				t.Errorf("formatting negative value [%d] should fail: got [%s], %v", value, seed, err)
+			}
+			return
+		}
+		if parsed, err := ParseSeed(seed); err != nil || parsed != value {
+			t.Errorf("seed [%s] of value [%d] does not parse back: got [%d], %v", seed, value, parsed, err)
+		}
+	})
+}
+
+func TestNewRNGUsesCanonicalSeed(t *testing.T) {
+	r, err := NewRNG("00MiChel")
+	if err != nil {
+		t.Errorf("failed to create RNG: %v", err)
+		return
+	}
+	if r.Seed() != "michel" {
+		t.Errorf("unexpected seed: wanted [michel], got [%s]", r.Seed())
+	}
+}
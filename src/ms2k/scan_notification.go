@@ -0,0 +1,74 @@
+package ms2k
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/timing"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/ui"
+)
+
+const (
+	scanNotificationDuration = 6 * time.Second
+	scanNotificationSlide    = 300 * time.Millisecond // time for the notification to slide in or out of the screen
+	scanNotificationWidth    = 420
+	scanNotificationPadding  = 8
+)
+
+// scanNotification tells the player what was found on a planet whose scan just completed
+type scanNotification struct {
+	planet            *Planet
+	slideIn, slideOut *timing.Tween // shares of the notification that are on screen
+	animation         timing.Animation
+}
+
+// visibleShare returns the share of the height of the notification that is on screen
+func (notification *scanNotification) visibleShare() float64 {
+	return min(notification.slideIn.Value(), notification.slideOut.Value())
+}
+
+// notifyScanCompleted shows the lore of the given planet, replacing any previous notification
+func (w *World) notifyScanCompleted(planet *Planet) {
+	if w.scanNotification != nil {
+		w.timeline.Stop(w.scanNotification.animation)
+	}
+	notification := &scanNotification{
+		planet:   planet,
+		slideIn:  timing.NewTween(0, 1, scanNotificationSlide, timing.EaseOut),
+		slideOut: timing.NewTween(1, 0, scanNotificationSlide, timing.EaseIn),
+	}
+	notification.animation = w.timeline.Play(timing.NewSequence(
+		notification.slideIn,
+		timing.Wait(scanNotificationDuration-2*scanNotificationSlide),
+		notification.slideOut,
+		timing.Call(func() {
+			if w.scanNotification == notification {
+				w.scanNotification = nil
+			}
+		}),
+	))
+	w.scanNotification = notification
+}
+
+// drawScanNotification draws the current scan notification, if any, at the top of the screen.
+// It does not block the game and disappears after a while.
+func (w *World) drawScanNotification(screen *ebiten.Image) {
+	if w.scanNotification == nil {
+		return
+	}
+
+	planet := w.scanNotification.planet
+	titleLineHeight := ui.Metrics(w.assetLibrary, ui.BodyText).LineHeight
+	lines, _ := ui.SplitWallOfText(w.assetLibrary, scanNotificationWidth-2*scanNotificationPadding, 0, []string{planet.Lore})
+	title := planet.DisplayName() + " scanned"
+
+	width := max(scanNotificationWidth, ui.MeasureText(w.assetLibrary, ui.BodyText, title)+2*scanNotificationPadding)
+	height := titleLineHeight * (len(lines) + 1)
+	screenWidth := screen.Bounds().Dx()
+	x, y := (screenWidth-width)/2, -int(float64(height)*(1-w.scanNotification.visibleShare()))
+	ui.DrawBoxAround(screen, w.assetLibrary, x, y, width, height, ui.Left|ui.Bottom|ui.Right)
+
+	ui.DrawText(screen, w.assetLibrary, ui.BodyText, title, screenWidth/2, y, ui.AlignCenter, ui.SelectedTextColor)
+	ui.DrawWallOfText(screen, w.assetLibrary, x+scanNotificationPadding, y+titleLineHeight, lines, 0, len(lines))
+}
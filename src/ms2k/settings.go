@@ -1,19 +1,28 @@
 package ms2k
 
 import (
+	"image/color"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text"
 
 	"github.com/RemiEven/michelSpace2000/src/ms2k/assets"
 	"github.com/RemiEven/michelSpace2000/src/ms2k/audio"
 	"github.com/RemiEven/michelSpace2000/src/ms2k/ui"
 )
 
+const (
+	settingsOptionKeyMapping = iota
+	settingsOptionLargeText
+	numberOfSettingsOptions
+)
+
 // Settings holds the settings of the game
 type Settings struct {
 	keyboardLayout          string
 	selectedKeyMappingIndex int
+	largeText               bool
+	selectedOption          int
 	assetLibrary            *assets.Library
 }
 
@@ -31,11 +40,22 @@ func (settings *Settings) Update() int8 {
 		return stateInMenu
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyRight) {
-		settings.selectedKeyMappingIndex++
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		settings.selectedOption = (settings.selectedOption + 1) % numberOfSettingsOptions
 	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		settings.selectedOption = (settings.selectedOption + numberOfSettingsOptions - 1) % numberOfSettingsOptions
+	}
+
+	optionChanged := inpututil.IsKeyJustPressed(ebiten.KeyRight) || inpututil.IsKeyJustPressed(ebiten.KeyLeft)
+	switch {
+	case settings.selectedOption == settingsOptionKeyMapping && inpututil.IsKeyJustPressed(ebiten.KeyRight):
+		settings.selectedKeyMappingIndex++
+	case settings.selectedOption == settingsOptionKeyMapping && inpututil.IsKeyJustPressed(ebiten.KeyLeft):
 		settings.selectedKeyMappingIndex--
+	case settings.selectedOption == settingsOptionLargeText && optionChanged:
+		settings.largeText = !settings.largeText
+		ui.SetLargeText(settings.largeText)
 	}
 	settings.selectedKeyMappingIndex %= len(keyboardLayouts)
 	if settings.selectedKeyMappingIndex < 0 {
@@ -48,71 +68,43 @@ func (settings *Settings) Update() int8 {
 
 // Draw draws the settings
 func (settings *Settings) Draw(screen *ebiten.Image) {
-	drawSpaceBackground(screen, settings.assetLibrary, Position{})
+	drawSpaceBackground(screen, settings.assetLibrary, Position{}, 1)
 
-	screenWidth := screen.Bounds().Dx()
+	centerX := screen.Bounds().Dx() / 2
+	lineHeight := ui.Metrics(settings.assetLibrary, ui.BodyText).LineHeight
 
-	fontFace, _ := settings.assetLibrary.FontFaces.Load("oxanium")
-	fontFaceHeight := fontFace.Metrics().Height.Ceil()
-	fontShift := (fontFace.Metrics().Ascent + (fontFace.Metrics().Height-fontFace.Metrics().Ascent-fontFace.Metrics().Descent)/2).Ceil()
+	titleLabel := "MichelSpace2000 - Controls"
+	boxWidth := ui.MeasureText(settings.assetLibrary, ui.TitleText, titleLabel)
 
-	longestLabel := "MichelSpace2000 - Controls"
-	largestBoundString := text.BoundString(fontFace, longestLabel)
+	ui.DrawTextInBox(screen, settings.assetLibrary, ui.TitleText, titleLabel, centerX, lineHeight*3, boxWidth, ui.AllBorders, ui.TextColor)
 
-	{
-		titleLabel := "MichelSpace2000 - Controls"
-		boundString := text.BoundString(fontFace, titleLabel)
-		ui.DrawBoxAround(screen, settings.assetLibrary, (screenWidth-largestBoundString.Dx())/2, fontFaceHeight*5, largestBoundString.Dx(), fontFaceHeight, ui.AllBorders)
-		text.Draw(screen, titleLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*5+fontShift, ui.TextColor)
+	color := func(option int) color.Color {
+		if option == settings.selectedOption {
+			return ui.SelectedTextColor
+		}
+		return ui.TextColor
 	}
-
-	{
-		keyMappingLabel := "Key mapping: < " + settings.keyboardLayout + " >"
-		boundString := text.BoundString(fontFace, keyMappingLabel)
-		ui.DrawBoxAround(screen, settings.assetLibrary, (screenWidth-largestBoundString.Dx())/2, fontFaceHeight*9, largestBoundString.Dx(), fontFaceHeight, ui.AllBorders)
-		text.Draw(screen, keyMappingLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*9+fontShift, ui.TextColor)
+	largeTextLabel := "Off"
+	if settings.largeText {
+		largeTextLabel = "On"
 	}
+	ui.DrawTextInBox(screen, settings.assetLibrary, ui.BodyText, "Key mapping: < "+settings.keyboardLayout+" >", centerX, lineHeight*6, boxWidth, ui.AllBorders, color(settingsOptionKeyMapping))
+	ui.DrawTextInBox(screen, settings.assetLibrary, ui.BodyText, "Large text: < "+largeTextLabel+" >", centerX, lineHeight*8, boxWidth, ui.AllBorders, color(settingsOptionLargeText))
 
-	ui.DrawBoxAround(screen, settings.assetLibrary, (screenWidth-largestBoundString.Dx())/2, fontFaceHeight*11, largestBoundString.Dx(), fontFaceHeight*8, ui.AllBorders)
-
-	{
-		previousShipLabel := "Select previous ship: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.PreviousShip)
-		boundString := text.BoundString(fontFace, previousShipLabel)
-		text.Draw(screen, previousShipLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*11+fontShift, ui.TextColor)
-	}
-	{
-		nextShipLabel := "Select next ship: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.NextShip)
-		boundString := text.BoundString(fontFace, nextShipLabel)
-		text.Draw(screen, nextShipLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*12+fontShift, ui.TextColor)
-	}
-	{
-		zoomInLabel := "Zoom in: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.ZoomIn)
-		boundString := text.BoundString(fontFace, zoomInLabel)
-		text.Draw(screen, zoomInLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*13+fontShift, ui.TextColor)
-	}
-	{
-		zoomOutLabel := "Zoom out: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.ZoomOut)
-		boundString := text.BoundString(fontFace, zoomOutLabel)
-		text.Draw(screen, zoomOutLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*14+fontShift, ui.TextColor)
-	}
-	{
-		upLabel := "Go up: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.Up)
-		boundString := text.BoundString(fontFace, upLabel)
-		text.Draw(screen, upLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*15+fontShift, ui.TextColor)
-	}
-	{
-		downLabel := "Go down: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.Down)
-		boundString := text.BoundString(fontFace, downLabel)
-		text.Draw(screen, downLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*16+fontShift, ui.TextColor)
-	}
-	{
-		leftLabel := "Go left: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.Left)
-		boundString := text.BoundString(fontFace, leftLabel)
-		text.Draw(screen, leftLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*17+fontShift, ui.TextColor)
-	}
-	{
-		rightLabel := "Go right: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.Right)
-		boundString := text.BoundString(fontFace, rightLabel)
-		text.Draw(screen, rightLabel, fontFace, (screenWidth-boundString.Dx())/2, fontFaceHeight*18+fontShift, ui.TextColor)
+	ui.DrawBoxAround(screen, settings.assetLibrary, centerX-boxWidth/2, lineHeight*10, boxWidth, lineHeight*11, ui.AllBorders)
+	for i, label := range []string{
+		"Select previous ship: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.PreviousShip),
+		"Select next ship: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.NextShip),
+		"Zoom in: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.ZoomIn),
+		"Zoom out: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.ZoomOut),
+		"Toggle free look: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.FreeLook),
+		"Look at Earth: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.FocusEarth),
+		"Toggle galaxy map: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.ToggleMap),
+		"Go up: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.Up),
+		"Go down: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.Down),
+		"Go left: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.Left),
+		"Go right: " + ebitenKeyToString(settings.keyboardLayout, keyMapping.Right),
+	} {
+		ui.DrawText(screen, settings.assetLibrary, ui.BodyText, label, centerX, lineHeight*(10+i), ui.AlignCenter, ui.TextColor)
 	}
 }
@@ -0,0 +1,192 @@
+// Package sharecode packs everything needed to play the same game again into a short string that players can share.
+//
+// A share code starts with a prefix, followed by groups of base 32 characters separated by dashes.
+// Once decoded, its bytes hold the format version and the game options, the seed as a variable length integer,
+// then a checksum of everything before it, so that typos are detected instead of leading to another game.
+package sharecode
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/rng"
+)
+
+const (
+	// Prefix starts every share code, which tells them apart from plain seeds
+	Prefix = "ms-"
+	// Version is the version of the share code format
+	Version = 1
+	// MaxLength is the length of the longest share code
+	MaxLength = len(Prefix) + 24
+
+	groupLength    = 5
+	checksumLength = 2
+	// alphabet is the one of Crockford's base 32, which avoids characters that look alike
+	alphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+)
+
+// Difficulty sets how fast the doomsday clock runs
+type Difficulty uint8
+
+// Enum of all difficulties
+const (
+	Relaxed Difficulty = iota
+	Normal
+	Hard
+	numberOfDifficulties
+)
+
+// String returns the name of the difficulty
+func (difficulty Difficulty) String() string {
+	return [...]string{"relaxed", "normal", "hard"}[difficulty]
+}
+
+// Next returns the difficulty that comes after this one, wrapping around
+func (difficulty Difficulty) Next() Difficulty {
+	return (difficulty + 1) % numberOfDifficulties
+}
+
+// Previous returns the difficulty that comes before this one, wrapping around
+func (difficulty Difficulty) Previous() Difficulty {
+	return (difficulty + numberOfDifficulties - 1) % numberOfDifficulties
+}
+
+// Options are the rules of a game
+type Options struct {
+	Difficulty Difficulty
+	FogOfWar   bool // whether the galaxy is hidden until ships explore it
+}
+
+// DefaultOptions are the options of a game when the player does not change them
+var DefaultOptions = Options{
+	Difficulty: Normal,
+	FogOfWar:   true,
+}
+
+// ErrInvalidCode is returned when a string is not a share code
+var ErrInvalidCode = errors.New("invalid share code")
+
+// Encode returns the share code of a game with the given seed and options
+func Encode(seed string, options Options) (string, error) {
+	seedValue, err := rng.ParseSeed(seed)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse seed [%s]: %w", seed, err)
+	}
+
+	data := []byte{Version<<4 | byte(options.Difficulty)<<1}
+	if options.FogOfWar {
+		data[0] |= 1
+	}
+	data = binary.AppendUvarint(data, uint64(seedValue))
+	data = binary.BigEndian.AppendUint16(data, checksum(data))
+
+	encoded := encodeBase32(data)
+	groups := []string{}
+	for len(encoded) > groupLength {
+		groups = append(groups, encoded[:groupLength])
+		encoded = encoded[groupLength:]
+	}
+	groups = append(groups, encoded)
+	return Prefix + strings.Join(groups, "-"), nil
+}
+
+// IsShareCode returns whether the given string looks like a share code rather than a seed, without checking that it is valid.
+// Share codes start with the prefix, whose dash may have become a space when copied by hand, which seeds can not contain.
+func IsShareCode(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+	name := strings.TrimSuffix(Prefix, "-")
+	return len(s) > len(name) && strings.HasPrefix(s, name) && (s[len(name)] == '-' || s[len(name)] == ' ')
+}
+
+// normalize removes from a share code what does not matter, and replaces the characters that look like the ones of the alphabet
+func normalize(code string) string {
+	return strings.NewReplacer("-", "", " ", "", "i", "1", "l", "1", "o", "0").Replace(strings.ToLower(code))
+}
+
+// Decode returns the seed and the options of the game of a share code.
+// Case, spaces and dashes are ignored, so that codes survive being copied by hand.
+func Decode(code string) (string, Options, error) {
+	if !IsShareCode(code) {
+		return "", Options{}, fmt.Errorf("%w: missing prefix [%s]", ErrInvalidCode, Prefix)
+	}
+	data, err := decodeBase32(strings.TrimPrefix(normalize(code), normalize(Prefix)))
+	if err != nil {
+		return "", Options{}, err
+	}
+	if len(data) < 1+1+checksumLength {
+		return "", Options{}, fmt.Errorf("%w: too short", ErrInvalidCode)
+	}
+	payload := data[:len(data)-checksumLength]
+	if binary.BigEndian.Uint16(data[len(payload):]) != checksum(payload) {
+		return "", Options{}, fmt.Errorf("%w: checksum mismatch", ErrInvalidCode)
+	}
+
+	if version := payload[0] >> 4; version != Version {
+		return "", Options{}, fmt.Errorf("%w: unsupported version [%d]", ErrInvalidCode, version)
+	}
+	options := Options{
+		Difficulty: Difficulty(payload[0] >> 1 & 0b111),
+		FogOfWar:   payload[0]&1 != 0,
+	}
+	if options.Difficulty >= numberOfDifficulties {
+		return "", Options{}, fmt.Errorf("%w: unknown difficulty [%d]", ErrInvalidCode, options.Difficulty)
+	}
+
+	seedValue, n := binary.Uvarint(payload[1:])
+	if n <= 0 || 1+n != len(payload) || seedValue > 1<<63-1 {
+		return "", Options{}, fmt.Errorf("%w: malformed seed", ErrInvalidCode)
+	}
+	seed, err := rng.FormatSeed(int64(seedValue))
+	if err != nil {
+		return "", Options{}, fmt.Errorf("%w: %w", ErrInvalidCode, err)
+	}
+	return seed, options, nil
+}
+
+func checksum(data []byte) uint16 {
+	return uint16(crc32.ChecksumIEEE(data))
+}
+
+// encodeBase32 encodes bytes five bits at a time, without padding
+func encodeBase32(data []byte) string {
+	var sb strings.Builder
+	buffer, bits := uint(0), 0
+	for _, b := range data {
+		buffer = buffer<<8 | uint(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			sb.WriteByte(alphabet[buffer>>bits&0b11111])
+		}
+	}
+	if bits > 0 {
+		sb.WriteByte(alphabet[buffer<<(5-bits)&0b11111])
+	}
+	return sb.String()
+}
+
+// decodeBase32 decodes what encodeBase32 encoded
+func decodeBase32(s string) ([]byte, error) {
+	data := []byte{}
+	buffer, bits := uint(0), 0
+	for i, r := range s {
+		value := strings.IndexRune(alphabet, r)
+		if value < 0 {
+			return nil, fmt.Errorf("%w: invalid character [%c] at position %d", ErrInvalidCode, r, i)
+		}
+		buffer = buffer<<5 | uint(value)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			data = append(data, byte(buffer>>bits))
+		}
+	}
+	if buffer&(1<<bits-1) != 0 {
+		return nil, fmt.Errorf("%w: trailing bits", ErrInvalidCode)
+	}
+	return data, nil
+}
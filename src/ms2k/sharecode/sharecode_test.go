@@ -0,0 +1,89 @@
+package sharecode
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, seed := range []string{"0", "michel", "zzzzzzzz", "2000"} {
+		for _, options := range []Options{DefaultOptions, {Difficulty: Hard}, {Difficulty: Relaxed, FogOfWar: true}} {
+			code, err := Encode(seed, options)
+			if err != nil {
+				t.Errorf("failed to encode seed [%s]: %v", seed, err)
+				return
+			}
+			if len(code) > MaxLength {
+				t.Errorf("share code [%s] is longer than [%d]", code, MaxLength)
+				return
+			}
+			decodedSeed, decodedOptions, err := Decode(code)
+			if err != nil {
+				t.Errorf("failed to decode share code [%s]: %v", code, err)
+				return
+			}
+			if decodedSeed != seed || decodedOptions != options {
+				t.Errorf("unexpected decoded share code [%s]: wanted [%s %+v], got [%s %+v]", code, seed, options, decodedSeed, decodedOptions)
+				return
+			}
+		}
+	}
+}
+
+func TestDecodeIsLenient(t *testing.T) {
+	code, err := Encode("michel", DefaultOptions)
+	if err != nil {
+		t.Errorf("failed to encode seed: %v", err)
+		return
+	}
+	seed, _, err := Decode("  " + strings.ToUpper(strings.ReplaceAll(code, "-", " ")) + " ")
+	if err != nil || seed != "michel" {
+		t.Errorf("failed to decode a share code copied by hand: got [%s], %v", seed, err)
+	}
+}
+
+func TestDecodeDetectsTypos(t *testing.T) {
+	code, err := Encode("michel", DefaultOptions)
+	if err != nil {
+		t.Errorf("failed to encode seed: %v", err)
+		return
+	}
+	for i := len(Prefix); i < len(code); i++ {
+		if code[i] == '-' {
+			continue
+		}
+		for _, r := range alphabet {
+			if byte(r) == code[i] {
+				continue
+			}
+			typo := code[:i] + string(r) + code[i+1:]
+			if _, _, err := Decode(typo); !errors.Is(err, ErrInvalidCode) {
+				t.Errorf("typo [%s] of share code [%s] should be detected", typo, code)
+				return
+			}
+		}
+	}
+}
+
+func TestDecodeRejectsSeeds(t *testing.T) {
+	if _, _, err := Decode("michel"); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("a seed should not be decoded as a share code")
+	}
+}
+
+func TestSeedsStartingLikeThePrefixAreNotShareCodes(t *testing.T) {
+	for _, seed := range []string{"ms2k", "msjohn", "MS", " Ms1 "} {
+		if IsShareCode(seed) {
+			t.Errorf("seed [%s] should not be taken for a share code", seed)
+		}
+	}
+	code, err := Encode("ms2k", DefaultOptions)
+	if err != nil {
+		t.Errorf("failed to encode seed: %v", err)
+		return
+	}
+	if !IsShareCode(code) || !IsShareCode(strings.ReplaceAll(code, "-", " ")) {
+		t.Errorf("[%s] should be taken for a share code", code)
+	}
+}
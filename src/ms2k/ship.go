@@ -1,9 +1,88 @@
 package ms2k
 
-// Ship holds the position and direction of a spaceship
+import (
+	"math"
+	"time"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/physics"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/timing"
+)
+
+// ShipStats holds the characteristics of a spaceship, which upgrades can improve
+type ShipStats struct {
+	SensorRange float64 // distance up to which the ship explores space around it
+	Movement    physics.Tuning
+}
+
+var defaultShipStats = ShipStats{
+	SensorRange: 300,
+	Movement: physics.Tuning{
+		Acceleration: 720,
+		MaxSpeed:     180,
+		Drag:         2.5,
+		TurnRate:     3 * math.Pi,
+	},
+}
+
+// Ship holds the position and movement of a spaceship
 type Ship struct {
-	Position  Position
-	Direction Direction
+	Position Position
+	Velocity physics.Vector
+	Heading  float64 // angle in radians, 0 pointing north and growing clockwise
+	Stats    ShipStats
+	Docked   bool // docked ships stay in place, out of gravity, until they thrust
+
+	thrusting bool
+
+	PlanetScans map[*Planet]*timing.Progress
+	MoonScans   map[*Moon]*timing.Progress
+}
+
+// Step moves the ship according to its inertia, the given thrust and gravity during the given duration,
+// then pushes it out of the obstacles it ran into. The movement tuning is the one of the ship stats, as altered by its surroundings.
+func (s *Ship) Step(thrust, gravity physics.Vector, movement physics.Tuning, obstacles []obstacle, elapsed time.Duration) {
+	if s.Docked {
+		if thrust == (physics.Vector{}) {
+			return
+		}
+		s.Docked = false
+	}
+	body := physics.Body{
+		Position: physics.Vector(s.Position),
+		Velocity: s.Velocity,
+		Heading:  s.Heading,
+	}
+	physics.Accelerate(&body, gravity, elapsed)
+	physics.Step(&body, thrust, movement, elapsed)
+	for _, o := range obstacles {
+		physics.Collide(&body, shipCollisionRadius, o.shape, o.restitution)
+	}
+	s.thrusting = thrust != (physics.Vector{})
+	s.Position, s.Velocity, s.Heading = Position(body.Position), body.Velocity, body.Heading
+}
+
+// Forward returns the unit vector pointing where the ship is heading
+func (s *Ship) Forward() physics.Vector {
+	return physics.HeadingVector(s.Heading)
+}
+
+// scanProgress returns the completed percentage of the most advanced ongoing scan of the ship, if any
+func (s *Ship) scanProgress() (float64, bool) {
+	progress, scanning := 0.0, false
+	for _, scan := range s.PlanetScans {
+		progress, scanning = max(progress, scan.Percentage()), true
+	}
+	return progress, scanning
+}
 
-	PlanetScans map[*Planet]*Operation
+// scannedPlanet returns the closest of the planets the ship is scanning, if any
+func (s *Ship) scannedPlanet() *Planet {
+	var scannedPlanet *Planet
+	distanceToScannedPlanet := math.MaxFloat64
+	for planet := range s.PlanetScans {
+		if distance := s.Position.DistanceTo(&planet.Position); distance < distanceToScannedPlanet {
+			scannedPlanet, distanceToScannedPlanet = planet, distance
+		}
+	}
+	return scannedPlanet
 }
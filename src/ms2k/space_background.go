@@ -8,24 +8,24 @@ import (
 	"github.com/RemiEven/michelSpace2000/src/ms2k/assets"
 )
 
-func drawSpaceBackground(screen *ebiten.Image, assetLibrary *assets.Library, position Position) {
+func drawSpaceBackground(screen *ebiten.Image, assetLibrary *assets.Library, position Position, zoom float64) {
 	screenWidth, screenHeight := float64(screen.Bounds().Dx()), float64(screen.Bounds().Dy())
 	scale := 1.0
 
 	bg, _ := assetLibrary.Images.Load("bg")
 
-	parallaxFactor := math.Pow(3.0, zoomFactor)
+	parallaxFactor := math.Pow(3.0, zoom)
 	imageWidth, imageHeight := bg.Bounds().Dx(), bg.Bounds().Dy()
-	topLeftBackgroundTileX := int(math.Floor((((parallaxFactor-1.0)/parallaxFactor)*position.X - screenWidth/2 /*/zoomFactor*/) / (float64(imageWidth) * scale)))
-	topLeftBackgroundTileY := int(math.Floor((((parallaxFactor-1.0)/parallaxFactor)*position.Y - screenHeight/2 /*/zoomFactor*/) / (float64(imageHeight) * scale)))
-	bottomRightBackgroundTileX := int(math.Floor((((parallaxFactor-1.0)/parallaxFactor)*position.X + screenWidth/2 /*/zoomFactor*/) / (float64(imageWidth) * scale)))
-	bottomRightBackgroundTileY := int(math.Floor((((parallaxFactor-1.0)/parallaxFactor)*position.Y + screenHeight/2 /*/zoomFactor*/) / (float64(imageHeight) * scale)))
+	topLeftBackgroundTileX := int(math.Floor((((parallaxFactor-1.0)/parallaxFactor)*position.X - screenWidth/2 /*/zoom*/) / (float64(imageWidth) * scale)))
+	topLeftBackgroundTileY := int(math.Floor((((parallaxFactor-1.0)/parallaxFactor)*position.Y - screenHeight/2 /*/zoom*/) / (float64(imageHeight) * scale)))
+	bottomRightBackgroundTileX := int(math.Floor((((parallaxFactor-1.0)/parallaxFactor)*position.X + screenWidth/2 /*/zoom*/) / (float64(imageWidth) * scale)))
+	bottomRightBackgroundTileY := int(math.Floor((((parallaxFactor-1.0)/parallaxFactor)*position.Y + screenHeight/2 /*/zoom*/) / (float64(imageHeight) * scale)))
 	x := topLeftBackgroundTileX
 	for x <= bottomRightBackgroundTileX {
 		y := topLeftBackgroundTileY
 		for y <= bottomRightBackgroundTileY {
 			dio := &ebiten.DrawImageOptions{}
-			dio.GeoM.Translate(float64(x)*scale*float64(imageWidth)+screenWidth/2.0 /*/zoomFactor*/ -(parallaxFactor-1.0)*position.X/parallaxFactor, float64(y)*scale*float64(imageHeight)+screenHeight/2.0 /*/zoomFactor*/ -(parallaxFactor-1.0)*position.Y/parallaxFactor)
+			dio.GeoM.Translate(float64(x)*scale*float64(imageWidth)+screenWidth/2.0 /*/zoom*/ -(parallaxFactor-1.0)*position.X/parallaxFactor, float64(y)*scale*float64(imageHeight)+screenHeight/2.0 /*/zoom*/ -(parallaxFactor-1.0)*position.Y/parallaxFactor)
 			screen.DrawImage(bg, dio)
 			y++
 		}
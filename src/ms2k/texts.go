@@ -1,5 +1,13 @@
 package ms2k
 
+import (
+	"strconv"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/generation"
+)
+
+var habitableThresholdText = strconv.Itoa(int(100*generation.HabitableThreshold)) + "%"
+
 var intro = []string{
 	`The end is nigh!
 The inaction of Earth leaders, unable or unwilling to see past their greed, has led to the overexploitation and pollution of the planet for the benefits of the ultra rich.`,
@@ -7,4 +15,6 @@ The inaction of Earth leaders, unable or unwilling to see past their greed, has
 	`In a desperate move, a group of scientists has launched an autonomous probe on a quest to find a planet that could be habitable enough to become a new home for humans.`,
 	`Given the colossal size of that task, the probe is designed to replicate and upgrade itself during its odyssey.
 Will it succeed in scanning enough worlds to find the perfect planet before it is too late?`,
+	`Scanning a planet reveals how habitable it is. Humanity would settle for any world scoring at least ` + habitableThresholdText + ` of habitability.`,
+	`Every promising world and every moon scanned gives people on Earth hope, and pushes the doomsday clock back a little. But should the probe find nothing for too long, despair will make the clock run faster.`,
 }
@@ -0,0 +1,37 @@
+package timing
+
+import "math"
+
+// Easing maps the progress of an animation, between 0 and 1, to the share of the change of its value, 0 at the start and 1 at the end
+type Easing func(progress float64) float64
+
+// Linear changes the value at a constant rate
+func Linear(progress float64) float64 {
+	return progress
+}
+
+// EaseIn starts slowly and accelerates
+func EaseIn(progress float64) float64 {
+	return progress * progress * progress
+}
+
+// EaseOut starts fast and decelerates
+func EaseOut(progress float64) float64 {
+	return 1 - EaseIn(1-progress)
+}
+
+// EaseInOut accelerates during the first half and decelerates during the second one
+func EaseInOut(progress float64) float64 {
+	if progress < 0.5 {
+		return 4 * progress * progress * progress
+	}
+	return 1 - 4*math.Pow(1-progress, 3)
+}
+
+// Step keeps the start value until the end
+func Step(progress float64) float64 {
+	if progress < 1 {
+		return 0
+	}
+	return 1
+}
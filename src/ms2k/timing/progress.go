@@ -0,0 +1,83 @@
+package timing
+
+import "time"
+
+// maxProgressHistory is the number of shifts a progress remembers
+const maxProgressHistory = 32
+
+// Progress measures how much of something is done, in percents, growing at a given speed along the time of a clock.
+// Unlike a tween, its speed can change and it can be shifted forward or back, like a scan or a countdown.
+type Progress struct {
+	clock      *Clock
+	percentage float64
+	lastUpdate time.Duration
+	speed      float64
+	history    []ProgressShift
+}
+
+// ProgressShift is a sudden change of a progress
+type ProgressShift struct {
+	At     time.Duration // time of the clock of the progress
+	Amount float64       // percents added to the progress, negative when it went back
+	Reason string
+}
+
+// NewProgress creates a progress starting now on the given clock, at the given speed in percents per second
+func NewProgress(clock *Clock, speed float64) *Progress {
+	return &Progress{
+		clock:      clock,
+		lastUpdate: clock.Now(),
+		speed:      speed,
+	}
+}
+
+// Update makes the progress grow by the time elapsed on its clock since the last update
+func (progress *Progress) Update() {
+	progress.percentage += progress.clock.Since(progress.lastUpdate).Seconds() * progress.speed
+	progress.lastUpdate = progress.clock.Now()
+}
+
+// Hold lets the time elapsed since the last update pass without growing
+func (progress *Progress) Hold() {
+	progress.lastUpdate = progress.clock.Now()
+}
+
+// Speed returns the current speed of the progress, in percents per second
+func (progress *Progress) Speed() float64 {
+	return progress.speed
+}
+
+// SetSpeed changes the speed of the progress from now on. The time until now counts at the previous speed.
+func (progress *Progress) SetSpeed(speed float64) {
+	progress.Update()
+	progress.speed = speed
+}
+
+// Shift adds the given amount of percents to the progress, or removes them if it is negative, and remembers why
+func (progress *Progress) Shift(amount float64, reason string) {
+	previousPercentage := progress.percentage
+	progress.percentage = max(0, progress.percentage+amount)
+	progress.history = append(progress.history, ProgressShift{
+		At:     progress.clock.Now(),
+		Amount: progress.percentage - previousPercentage,
+		Reason: reason,
+	})
+	if len(progress.history) > maxProgressHistory {
+		progress.history = progress.history[len(progress.history)-maxProgressHistory:]
+	}
+}
+
+// History returns the last shifts of the progress, oldest first
+func (progress *Progress) History() []ProgressShift {
+	return progress.history
+}
+
+// Percentage returns how much is done, which may exceed 100 when the progress went past its completion
+func (progress *Progress) Percentage() float64 {
+	return progress.percentage
+}
+
+// IsCompleted returns whether the progress reached 100 percents
+func (progress *Progress) IsCompleted() bool {
+	return progress.percentage >= 100
+}
@@ -0,0 +1,54 @@
+package timing
+
+import "time"
+
+// Sequence plays animations one after the other
+type Sequence struct {
+	steps   []Animation
+	current int
+}
+
+// NewSequence creates a sequence of the given animations, played in order
+func NewSequence(steps ...Animation) *Sequence {
+	return &Sequence{steps: steps}
+}
+
+// Advance makes the current step progress, and the next ones with the time it left over
+func (sequence *Sequence) Advance(elapsed time.Duration) time.Duration {
+	for sequence.current < len(sequence.steps) {
+		elapsed = sequence.steps[sequence.current].Advance(elapsed)
+		if !sequence.steps[sequence.current].IsCompleted() {
+			return 0
+		}
+		sequence.current++
+	}
+	return elapsed
+}
+
+// IsCompleted returns whether all steps of the sequence are completed
+func (sequence *Sequence) IsCompleted() bool {
+	return sequence.current == len(sequence.steps)
+}
+
+// callback is an animation that completes at once by calling a function
+type callback struct {
+	call      func()
+	completed bool
+}
+
+// Call creates an animation that calls the given function when it is reached, typically at the end of a sequence
+func Call(call func()) Animation {
+	return &callback{call: call}
+}
+
+func (c *callback) Advance(elapsed time.Duration) time.Duration {
+	if !c.completed {
+		c.completed = true
+		c.call()
+	}
+	return elapsed
+}
+
+func (c *callback) IsCompleted() bool {
+	return c.completed
+}
@@ -0,0 +1,55 @@
+package timing
+
+import "time"
+
+// Timeline plays animations along the time of a clock
+type Timeline struct {
+	clock      *Clock
+	lastUpdate time.Duration
+	animations []Animation
+}
+
+// NewTimeline creates a timeline following the given clock
+func NewTimeline(clock *Clock) *Timeline {
+	return &Timeline{
+		clock:      clock,
+		lastUpdate: clock.Now(),
+	}
+}
+
+// Play starts the given animation from the current time of the clock, and returns it
+func (timeline *Timeline) Play(animation Animation) Animation {
+	timeline.animations = append(timeline.animations, animation)
+	return animation
+}
+
+// Stop removes the given animation from the timeline, without completing it
+func (timeline *Timeline) Stop(animation Animation) {
+	for i, playing := range timeline.animations {
+		if playing == animation {
+			timeline.animations = append(timeline.animations[:i], timeline.animations[i+1:]...)
+			return
+		}
+	}
+}
+
+// Update advances the animations by the time elapsed on the clock since the last update, and forgets those that completed
+func (timeline *Timeline) Update() {
+	elapsed := timeline.clock.Since(timeline.lastUpdate)
+	timeline.lastUpdate = timeline.clock.Now()
+
+	// completion callbacks may play new animations, which start at the next update
+	animations := timeline.animations
+	timeline.animations = nil
+	for _, animation := range animations {
+		animation.Advance(elapsed)
+		if !animation.IsCompleted() {
+			timeline.animations = append(timeline.animations, animation)
+		}
+	}
+}
+
+// Len returns the number of animations being played
+func (timeline *Timeline) Len() int {
+	return len(timeline.animations)
+}
@@ -0,0 +1,161 @@
+package timing
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestClockScale(t *testing.T) {
+	clock := NewClock()
+	clock.Advance(time.Second)
+	clock.SetScale(0.5)
+	if elapsed := clock.Advance(time.Second); elapsed != 500*time.Millisecond {
+		t.Errorf("a clock in slow motion should advance by half the time: got [%v]", elapsed)
+		return
+	}
+	clock.SetScale(0)
+	clock.Advance(time.Hour)
+	if !clock.IsPaused() || clock.Now() != 1500*time.Millisecond {
+		t.Errorf("a paused clock should not move: got [%v]", clock.Now())
+		return
+	}
+	if since := clock.Since(time.Second); since != 500*time.Millisecond {
+		t.Errorf("unexpected time since the first second: got [%v]", since)
+	}
+}
+
+func TestEasingsGoFromStartToEnd(t *testing.T) {
+	for name, easing := range map[string]Easing{"linear": Linear, "in": EaseIn, "out": EaseOut, "in-out": EaseInOut, "step": Step} {
+		if start, end := easing(0), easing(1); start != 0 || math.Abs(end-1) > 1e-9 {
+			t.Errorf("easing [%s] should go from 0 to 1: got [%v] and [%v]", name, start, end)
+		}
+	}
+	if EaseIn(0.5) >= 0.5 || EaseOut(0.5) <= 0.5 || math.Abs(EaseInOut(0.5)-0.5) > 1e-9 {
+		t.Errorf("unexpected easings at mid course: got [%v] [%v] [%v]", EaseIn(0.5), EaseOut(0.5), EaseInOut(0.5))
+	}
+}
+
+func TestTween(t *testing.T) {
+	completions := 0
+	tween := NewTween(10, 20, 2*time.Second, Linear).OnComplete(func() { completions++ })
+
+	if leftOver := tween.Advance(time.Second); leftOver != 0 || tween.Value() != 15 {
+		t.Errorf("a tween should be halfway after half its duration: got [%v] with [%v] left over", tween.Value(), leftOver)
+		return
+	}
+	if leftOver := tween.Advance(3 * time.Second); leftOver != 2*time.Second || tween.Value() != 20 {
+		t.Errorf("a tween should stop at its end value and leave the extra time: got [%v] with [%v] left over", tween.Value(), leftOver)
+		return
+	}
+	tween.Advance(time.Second)
+	if !tween.IsCompleted() || completions != 1 {
+		t.Errorf("a completed tween should have called back once: got [%d] calls", completions)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	order := []string{}
+	fadeIn := NewTween(0, 1, time.Second, EaseOut).OnComplete(func() { order = append(order, "faded in") })
+	fadeOut := NewTween(1, 0, time.Second, EaseIn)
+	sequence := NewSequence(fadeIn, Wait(2*time.Second), fadeOut, Call(func() { order = append(order, "done") }))
+
+	sequence.Advance(1500 * time.Millisecond)
+	if !fadeIn.IsCompleted() || fadeOut.Value() != 1 {
+		t.Errorf("the time left over by a step should go to the next one only: got [%v] and [%v]", fadeIn.Value(), fadeOut.Value())
+		return
+	}
+	sequence.Advance(2 * time.Second)
+	if sequence.IsCompleted() || fadeOut.Progress() != 0.5 {
+		t.Errorf("the last tween should be halfway: got [%v]", fadeOut.Progress())
+		return
+	}
+	if leftOver := sequence.Advance(time.Second); !sequence.IsCompleted() || leftOver != 500*time.Millisecond {
+		t.Errorf("the sequence should be completed with time left over: got [%v]", leftOver)
+		return
+	}
+	if len(order) != 2 || order[0] != "faded in" || order[1] != "done" {
+		t.Errorf("unexpected callbacks: got [%v]", order)
+	}
+}
+
+func TestTimelineFollowsItsClock(t *testing.T) {
+	clock := NewClock()
+	timeline := NewTimeline(clock)
+	tween := NewTween(0, 100, 10*time.Second, Linear)
+	timeline.Play(tween)
+
+	clock.SetScale(2)
+	clock.Advance(time.Second)
+	timeline.Update()
+	if tween.Value() != 20 {
+		t.Errorf("a timeline should play at the speed of its clock: got [%v]", tween.Value())
+		return
+	}
+
+	clock.SetScale(0)
+	clock.Advance(time.Minute)
+	timeline.Update()
+	if tween.Value() != 20 {
+		t.Errorf("a paused clock should pause the timeline: got [%v]", tween.Value())
+		return
+	}
+
+	clock.SetScale(1)
+	clock.Advance(time.Minute)
+	timeline.Update()
+	if !tween.IsCompleted() || timeline.Len() != 0 {
+		t.Errorf("completed animations should leave the timeline: got [%d] left", timeline.Len())
+	}
+}
+
+func TestTimelineStop(t *testing.T) {
+	clock := NewClock()
+	timeline := NewTimeline(clock)
+	called := false
+	timeline.Play(NewSequence(Wait(time.Second), Call(func() { called = true })))
+	stopped := timeline.Play(Wait(time.Second))
+	timeline.Stop(stopped)
+
+	clock.Advance(time.Second)
+	timeline.Update()
+	if !called || stopped.IsCompleted() {
+		t.Errorf("only the animation left in the timeline should have completed")
+	}
+}
+
+func TestProgress(t *testing.T) {
+	clock := NewClock()
+	clock.Advance(time.Minute)
+	progress := NewProgress(clock, 10)
+
+	clock.Advance(2 * time.Second)
+	progress.Update()
+	if progress.Percentage() != 20 {
+		t.Errorf("a progress should grow from its creation at its speed: got [%v]", progress.Percentage())
+		return
+	}
+
+	clock.Advance(time.Second)
+	progress.Hold()
+	progress.SetSpeed(50)
+	clock.Advance(time.Second)
+	progress.Update()
+	if progress.Percentage() != 70 {
+		t.Errorf("a held progress should not grow, then grow at its new speed: got [%v]", progress.Percentage())
+		return
+	}
+
+	progress.Shift(-100, "setback")
+	progress.Shift(40, "bonus")
+	if history := progress.History(); len(history) != 2 || history[0].Amount != -70 || history[1].At != clock.Now() {
+		t.Errorf("shifts should be remembered, a progress not going below zero: got [%+v]", history)
+		return
+	}
+	clock.SetScale(0)
+	clock.Advance(time.Hour)
+	progress.Update()
+	if progress.IsCompleted() {
+		t.Errorf("a progress should not grow while its clock is paused: got [%v]", progress.Percentage())
+	}
+}
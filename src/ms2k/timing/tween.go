@@ -0,0 +1,84 @@
+package timing
+
+import "time"
+
+// Animation is something that progresses as time passes
+type Animation interface {
+	// Advance makes the animation progress by the given time, and returns the part of it left over once the animation completed
+	Advance(elapsed time.Duration) time.Duration
+	IsCompleted() bool
+}
+
+// Tween moves a value from a start to an end along an easing curve
+type Tween struct {
+	from, to   float64
+	duration   time.Duration
+	easing     Easing
+	elapsed    time.Duration
+	completed  bool
+	onComplete func()
+}
+
+// NewTween creates a tween from one value to another, lasting the given time.
+// A tween without easing changes its value linearly.
+func NewTween(from, to float64, duration time.Duration, easing Easing) *Tween {
+	if easing == nil {
+		easing = Linear
+	}
+	return &Tween{
+		from:     from,
+		to:       to,
+		duration: max(0, duration),
+		easing:   easing,
+	}
+}
+
+// Wait creates a tween that only lets the given time pass, to delay the next steps of a sequence
+func Wait(duration time.Duration) *Tween {
+	return NewTween(0, 1, duration, Linear)
+}
+
+// OnComplete sets a function called once, when the tween completes. It returns the tween so that it can be chained.
+func (tween *Tween) OnComplete(callback func()) *Tween {
+	tween.onComplete = callback
+	return tween
+}
+
+// Advance makes the tween progress by the given time
+func (tween *Tween) Advance(elapsed time.Duration) time.Duration {
+	if tween.completed {
+		return elapsed
+	}
+	tween.elapsed += elapsed
+	if tween.elapsed < tween.duration {
+		return 0
+	}
+	leftOver := tween.elapsed - tween.duration
+	tween.elapsed = tween.duration
+	tween.completed = true
+	if tween.onComplete != nil {
+		tween.onComplete()
+	}
+	return leftOver
+}
+
+// Progress returns the share of the duration of the tween that has passed, between 0 and 1
+func (tween *Tween) Progress() float64 {
+	if tween.duration == 0 {
+		if tween.completed {
+			return 1
+		}
+		return 0
+	}
+	return float64(tween.elapsed) / float64(tween.duration)
+}
+
+// Value returns the current value of the tween
+func (tween *Tween) Value() float64 {
+	return tween.from + (tween.to-tween.from)*tween.easing(tween.Progress())
+}
+
+// IsCompleted returns whether the tween reached its end value
+func (tween *Tween) IsCompleted() bool {
+	return tween.completed
+}
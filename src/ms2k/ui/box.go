@@ -21,115 +21,136 @@ const (
 	NoBorder   = BorderOption(0)
 )
 
-func DrawBoxAround(screen *ebiten.Image, assetLibrary *assets.Library, x, y, width, height int, borderOption BorderOption) {
-	if int(width)%2 == 1 {
-		width++
-	}
-	if int(height)%2 == 1 {
-		height++
+const (
+	boxScale      = 2 // box pieces are drawn twice as big as in ui/listbox
+	boxBorderSize = 6 // size in pixels of the borders in ui/listbox
+)
+
+// boxPiece identifies a part of ui/listbox used to draw boxes
+type boxPiece uint8
+
+// Enum of all box pieces
+const (
+	topBorder boxPiece = iota
+	bottomBorder
+	topLeftCorner
+	topRightCorner
+	bottomLeftCorner
+	bottomRightCorner
+	numberOfBoxPieces
+)
+
+// size returns the size in pixels of the piece in ui/listbox. Borders are one pixel wide, as they are stretched along the box.
+func (piece boxPiece) size() (width, height int) {
+	if piece == topBorder || piece == bottomBorder {
+		return 1, boxBorderSize
 	}
-	scale := 2
-	horizontalBorderHeightPx := 6
+	return boxBorderSize, boxBorderSize
+}
 
-	var (
-		drawLeftBorder   = (borderOption | Left) == borderOption
-		drawRightBorder  = (borderOption | Right) == borderOption
-		drawTopBorder    = (borderOption | Top) == borderOption
-		drawBottomBorder = (borderOption | Bottom) == borderOption
-	)
+// boxQuad is a piece of a box, placed on screen
+type boxQuad struct {
+	piece boxPiece
+	geoM  ebiten.GeoM
+}
 
-	intermediaryImage := ebiten.NewImage(
-		int(width/scale)+numberOfTrues(drawLeftBorder, drawRightBorder)*horizontalBorderHeightPx,
-		int(height/scale)+numberOfTrues(drawTopBorder, drawBottomBorder)*horizontalBorderHeightPx,
-	)
-	vector.DrawFilledRect(intermediaryImage, float32(numberOfTrues(drawLeftBorder)*horizontalBorderHeightPx), float32(numberOfTrues(drawTopBorder)*horizontalBorderHeightPx), float32(width/scale), float32(height/scale), BoxBgColor, false)
+// cachedBoxPieces are the pieces of the last ui/listbox image used to draw a box
+var (
+	cachedBoxBase   *ebiten.Image
+	cachedBoxPieces [numberOfBoxPieces]*ebiten.Image
+)
+
+// DrawBoxAround draws a box whose inside starts at x, y and has the given size, with borders around it.
+// Pieces of ui/listbox are stretched straight onto the screen, so that drawing a box allocates no image.
+func DrawBoxAround(screen *ebiten.Image, assetLibrary *assets.Library, x, y, width, height int, borderOption BorderOption) {
+	width, height = width+width%2, height+height%2
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(width), float32(height), BoxBgColor, false)
 
 	baseImage, _ := assetLibrary.Images.Load("ui/listbox")
+	pieces := getBoxPieces(baseImage)
+	quads, count := layoutBox(x, y, width, height, borderOption)
+	dio := &ebiten.DrawImageOptions{}
+	for _, quad := range quads[:count] {
+		dio.GeoM = quad.geoM
+		screen.DrawImage(pieces[quad.piece], dio)
+	}
+}
+
+// getBoxPieces returns the pieces of the given ui/listbox image, slicing them only when the image changes
+func getBoxPieces(baseImage *ebiten.Image) *[numberOfBoxPieces]*ebiten.Image {
+	if cachedBoxBase == baseImage {
+		return &cachedBoxPieces
+	}
 	baseImageWidth, baseImageHeight := baseImage.Bounds().Dx(), baseImage.Bounds().Dy()
+	cachedBoxBase = baseImage
+	cachedBoxPieces = [numberOfBoxPieces]*ebiten.Image{
+		topBorder:         baseImage.SubImage(image.Rect(40, 2, 41, 2+boxBorderSize)).(*ebiten.Image),
+		bottomBorder:      baseImage.SubImage(image.Rect(40, baseImageHeight-boxBorderSize-2, 41, baseImageHeight-2)).(*ebiten.Image),
+		topLeftCorner:     baseImage.SubImage(image.Rect(1, 2, 1+boxBorderSize, 2+boxBorderSize)).(*ebiten.Image),
+		topRightCorner:    baseImage.SubImage(image.Rect(baseImageWidth-boxBorderSize-2, 2, baseImageWidth-2, 2+boxBorderSize)).(*ebiten.Image),
+		bottomLeftCorner:  baseImage.SubImage(image.Rect(1, baseImageHeight-2-boxBorderSize, 1+boxBorderSize, baseImageHeight-2)).(*ebiten.Image),
+		bottomRightCorner: baseImage.SubImage(image.Rect(baseImageWidth-boxBorderSize-2, baseImageHeight-2-boxBorderSize, baseImageWidth-2, baseImageHeight-2)).(*ebiten.Image),
+	}
+	return &cachedBoxPieces
+}
 
+// layoutBox places the pieces of a box around the given inside, stretching the borders along the edges
+func layoutBox(x, y, width, height int, borderOption BorderOption) (quads [numberOfBoxPieces + 2]boxQuad, count int) {
 	var (
-		topBorder         = baseImage.SubImage(image.Rect(40, 2, 41, 2+horizontalBorderHeightPx)).(*ebiten.Image)
-		bottomBorder      = baseImage.SubImage(image.Rect(40, baseImageHeight-horizontalBorderHeightPx-2, 41, baseImageHeight-2)).(*ebiten.Image)
-		topLeftCorner     = baseImage.SubImage(image.Rect(1, 2, 1+horizontalBorderHeightPx, 2+horizontalBorderHeightPx)).(*ebiten.Image)
-		topRightCorner    = baseImage.SubImage(image.Rect(baseImageWidth-horizontalBorderHeightPx-2, 2, baseImageWidth-2, 2+horizontalBorderHeightPx)).(*ebiten.Image)
-		bottomLeftCorner  = baseImage.SubImage(image.Rect(1, baseImageHeight-2-horizontalBorderHeightPx, 1+horizontalBorderHeightPx, baseImageHeight-2)).(*ebiten.Image)
-		bottomRightCorner = baseImage.SubImage(image.Rect(baseImageWidth-horizontalBorderHeightPx-2, baseImageHeight-2-horizontalBorderHeightPx, baseImageWidth-2, baseImageHeight-2)).(*ebiten.Image)
+		drawLeftBorder   = borderOption&Left != 0
+		drawRightBorder  = borderOption&Right != 0
+		drawTopBorder    = borderOption&Top != 0
+		drawBottomBorder = borderOption&Bottom != 0
 	)
+	border := float64(boxScale * boxBorderSize)
+	left, top := float64(x), float64(y)
+	right, bottom := left+float64(width), top+float64(height)
 
-	if drawTopBorder {
-		dio := &ebiten.DrawImageOptions{}
-		dio.GeoM.Translate(float64(numberOfTrues(drawLeftBorder)*horizontalBorderHeightPx), 0)
-		for i := 0; i < int(width/scale); i++ {
-			intermediaryImage.DrawImage(topBorder, dio)
-			dio.GeoM.Translate(1, 0)
+	add := func(piece boxPiece, geoM ebiten.GeoM) {
+		quads[count] = boxQuad{piece: piece, geoM: geoM}
+		count++
+	}
+	stretched := func(length int, rotated bool, tx, ty float64) ebiten.GeoM {
+		geoM := ebiten.GeoM{}
+		geoM.Scale(float64(length), boxScale)
+		if rotated {
+			geoM.Rotate(-math.Pi / 2)
 		}
+		geoM.Translate(tx, ty)
+		return geoM
+	}
+	corner := func(tx, ty float64) ebiten.GeoM {
+		geoM := ebiten.GeoM{}
+		geoM.Scale(boxScale, boxScale)
+		geoM.Translate(tx, ty)
+		return geoM
 	}
 
+	if drawTopBorder {
+		add(topBorder, stretched(width, false, left, top-border))
+	}
 	if drawBottomBorder {
-		dio := &ebiten.DrawImageOptions{}
-		dio.GeoM.Translate(float64(numberOfTrues(drawLeftBorder)*horizontalBorderHeightPx), float64(height/scale+numberOfTrues(drawTopBorder)*horizontalBorderHeightPx))
-		for i := 0; i < int(width/scale); i++ {
-			intermediaryImage.DrawImage(bottomBorder, dio)
-			dio.GeoM.Translate(1, 0)
-		}
+		add(bottomBorder, stretched(width, false, left, bottom))
 	}
-
+	// vertical borders are the horizontal ones rotated, the top border becoming the left one
 	if drawLeftBorder {
-		dio := &ebiten.DrawImageOptions{}
-		dio.GeoM.Rotate(-math.Pi / 2)
-		dio.GeoM.Translate(0, 1+float64(numberOfTrues(drawTopBorder)*horizontalBorderHeightPx))
-		for i := 0; i < int(height/scale); i++ {
-			intermediaryImage.DrawImage(topBorder, dio)
-			dio.GeoM.Translate(0, 1)
-		}
+		add(topBorder, stretched(height, true, left-border, bottom))
 	}
-
 	if drawRightBorder {
-		dio := &ebiten.DrawImageOptions{}
-		dio.GeoM.Rotate(-math.Pi / 2)
-		dio.GeoM.Translate(0, 1)
-		dio.GeoM.Translate(float64(width/scale+numberOfTrues(drawLeftBorder)*horizontalBorderHeightPx), float64(numberOfTrues(drawTopBorder)*horizontalBorderHeightPx))
-		for i := 0; i < int(height/scale); i++ {
-			intermediaryImage.DrawImage(bottomBorder, dio)
-			dio.GeoM.Translate(0, 1)
-		}
+		add(bottomBorder, stretched(height, true, right, bottom))
 	}
 
 	if drawTopBorder && drawLeftBorder {
-		dio := &ebiten.DrawImageOptions{}
-		intermediaryImage.DrawImage(topLeftCorner, dio)
+		add(topLeftCorner, corner(left-border, top-border))
 	}
-
 	if drawTopBorder && drawRightBorder {
-		dio := &ebiten.DrawImageOptions{}
-		dio.GeoM.Translate(float64(width/scale+numberOfTrues(drawLeftBorder)*horizontalBorderHeightPx), 0)
-		intermediaryImage.DrawImage(topRightCorner, dio)
+		add(topRightCorner, corner(right, top-border))
 	}
-
 	if drawBottomBorder && drawLeftBorder {
-		dio := &ebiten.DrawImageOptions{}
-		dio.GeoM.Translate(0, float64(height/scale+numberOfTrues(drawTopBorder)*horizontalBorderHeightPx))
-		intermediaryImage.DrawImage(bottomLeftCorner, dio)
+		add(bottomLeftCorner, corner(left-border, bottom))
 	}
-
 	if drawBottomBorder && drawRightBorder {
-		dio := &ebiten.DrawImageOptions{}
-		dio.GeoM.Translate(float64(width/scale+numberOfTrues(drawLeftBorder)*horizontalBorderHeightPx), float64(height/scale+numberOfTrues(drawTopBorder)*horizontalBorderHeightPx))
-		intermediaryImage.DrawImage(bottomRightCorner, dio)
-	}
-
-	dio := &ebiten.DrawImageOptions{}
-	dio.GeoM.Scale(float64(scale), float64(scale))
-	dio.GeoM.Translate(float64(x-numberOfTrues(drawLeftBorder)*scale*horizontalBorderHeightPx), float64(y-numberOfTrues(drawTopBorder)*scale*horizontalBorderHeightPx))
-	screen.DrawImage(intermediaryImage, dio)
-}
-
-func numberOfTrues(conditions ...bool) int {
-	number := 0
-	for _, b := range conditions {
-		if b {
-			number++
-		}
+		add(bottomRightCorner, corner(right, bottom))
 	}
-	return number
+	return quads, count
 }
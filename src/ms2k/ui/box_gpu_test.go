@@ -0,0 +1,193 @@
+//go:build gpu
+
+package ui
+
+import (
+	"errors"
+	"image"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/assets"
+)
+
+// These benchmarks draw on the GPU, so they need a display and only run with: go test -tags gpu -bench . ./src/ms2k/ui
+
+var errBenchmarksDone = errors.New("benchmarks done")
+
+// benchmarkGame runs the benchmarks inside the game loop, as ebiten only allows drawing once the game runs
+type benchmarkGame struct {
+	m    *testing.M
+	code int
+}
+
+func (g *benchmarkGame) Update() error {
+	g.code = g.m.Run()
+	return errBenchmarksDone
+}
+
+func (*benchmarkGame) Draw(*ebiten.Image) {}
+
+func (*benchmarkGame) Layout(int, int) (int, int) {
+	return 320, 240
+}
+
+func TestMain(m *testing.M) {
+	g := &benchmarkGame{m: m}
+	if err := ebiten.RunGame(g); err != nil && !errors.Is(err, errBenchmarksDone) {
+		panic(err)
+	}
+	os.Exit(g.code)
+}
+
+func newBenchmarkLibrary() *assets.Library {
+	al := &assets.Library{}
+	al.Images.Store("ui/listbox", ebiten.NewImage(64, 32))
+	return al
+}
+
+// drawMenuFrame draws boxes like a menu and the HUD do on each frame, the HUD changing width as its text changes
+func drawMenuFrame(screen *ebiten.Image, al *assets.Library, frame int, drawBox func(*ebiten.Image, *assets.Library, int, int, int, int, BorderOption)) {
+	for i := 0; i < 10; i++ {
+		drawBox(screen, al, 100, 40*i, 300, 30, AllBorders)
+	}
+	drawBox(screen, al, 0, 0, 200+frame%40, 60, Bottom|Right)
+}
+
+func BenchmarkDrawBoxAround(b *testing.B) {
+	al := newBenchmarkLibrary()
+	screen := ebiten.NewImage(640, 480)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		drawMenuFrame(screen, al, i, DrawBoxAround)
+	}
+}
+
+func BenchmarkDrawBoxAroundPixelByPixel(b *testing.B) {
+	al := newBenchmarkLibrary()
+	screen := ebiten.NewImage(640, 480)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		drawMenuFrame(screen, al, i, drawBoxAroundPixelByPixel)
+	}
+}
+
+// drawBoxAroundPixelByPixel is how boxes were drawn before pieces were stretched, kept to compare allocations
+func drawBoxAroundPixelByPixel(screen *ebiten.Image, assetLibrary *assets.Library, x, y, width, height int, borderOption BorderOption) {
+	if int(width)%2 == 1 {
+		width++
+	}
+	if int(height)%2 == 1 {
+		height++
+	}
+	scale := 2
+	horizontalBorderHeightPx := 6
+
+	var (
+		drawLeftBorder   = (borderOption | Left) == borderOption
+		drawRightBorder  = (borderOption | Right) == borderOption
+		drawTopBorder    = (borderOption | Top) == borderOption
+		drawBottomBorder = (borderOption | Bottom) == borderOption
+	)
+
+	intermediaryImage := ebiten.NewImage(
+		int(width/scale)+numberOfTrues(drawLeftBorder, drawRightBorder)*horizontalBorderHeightPx,
+		int(height/scale)+numberOfTrues(drawTopBorder, drawBottomBorder)*horizontalBorderHeightPx,
+	)
+	vector.DrawFilledRect(intermediaryImage, float32(numberOfTrues(drawLeftBorder)*horizontalBorderHeightPx), float32(numberOfTrues(drawTopBorder)*horizontalBorderHeightPx), float32(width/scale), float32(height/scale), BoxBgColor, false)
+
+	baseImage, _ := assetLibrary.Images.Load("ui/listbox")
+	baseImageWidth, baseImageHeight := baseImage.Bounds().Dx(), baseImage.Bounds().Dy()
+
+	var (
+		topBorder         = baseImage.SubImage(image.Rect(40, 2, 41, 2+horizontalBorderHeightPx)).(*ebiten.Image)
+		bottomBorder      = baseImage.SubImage(image.Rect(40, baseImageHeight-horizontalBorderHeightPx-2, 41, baseImageHeight-2)).(*ebiten.Image)
+		topLeftCorner     = baseImage.SubImage(image.Rect(1, 2, 1+horizontalBorderHeightPx, 2+horizontalBorderHeightPx)).(*ebiten.Image)
+		topRightCorner    = baseImage.SubImage(image.Rect(baseImageWidth-horizontalBorderHeightPx-2, 2, baseImageWidth-2, 2+horizontalBorderHeightPx)).(*ebiten.Image)
+		bottomLeftCorner  = baseImage.SubImage(image.Rect(1, baseImageHeight-2-horizontalBorderHeightPx, 1+horizontalBorderHeightPx, baseImageHeight-2)).(*ebiten.Image)
+		bottomRightCorner = baseImage.SubImage(image.Rect(baseImageWidth-horizontalBorderHeightPx-2, baseImageHeight-2-horizontalBorderHeightPx, baseImageWidth-2, baseImageHeight-2)).(*ebiten.Image)
+	)
+
+	if drawTopBorder {
+		dio := &ebiten.DrawImageOptions{}
+		dio.GeoM.Translate(float64(numberOfTrues(drawLeftBorder)*horizontalBorderHeightPx), 0)
+		for i := 0; i < int(width/scale); i++ {
+			intermediaryImage.DrawImage(topBorder, dio)
+			dio.GeoM.Translate(1, 0)
+		}
+	}
+
+	if drawBottomBorder {
+		dio := &ebiten.DrawImageOptions{}
+		dio.GeoM.Translate(float64(numberOfTrues(drawLeftBorder)*horizontalBorderHeightPx), float64(height/scale+numberOfTrues(drawTopBorder)*horizontalBorderHeightPx))
+		for i := 0; i < int(width/scale); i++ {
+			intermediaryImage.DrawImage(bottomBorder, dio)
+			dio.GeoM.Translate(1, 0)
+		}
+	}
+
+	if drawLeftBorder {
+		dio := &ebiten.DrawImageOptions{}
+		dio.GeoM.Rotate(-math.Pi / 2)
+		dio.GeoM.Translate(0, 1+float64(numberOfTrues(drawTopBorder)*horizontalBorderHeightPx))
+		for i := 0; i < int(height/scale); i++ {
+			intermediaryImage.DrawImage(topBorder, dio)
+			dio.GeoM.Translate(0, 1)
+		}
+	}
+
+	if drawRightBorder {
+		dio := &ebiten.DrawImageOptions{}
+		dio.GeoM.Rotate(-math.Pi / 2)
+		dio.GeoM.Translate(0, 1)
+		dio.GeoM.Translate(float64(width/scale+numberOfTrues(drawLeftBorder)*horizontalBorderHeightPx), float64(numberOfTrues(drawTopBorder)*horizontalBorderHeightPx))
+		for i := 0; i < int(height/scale); i++ {
+			intermediaryImage.DrawImage(bottomBorder, dio)
+			dio.GeoM.Translate(0, 1)
+		}
+	}
+
+	if drawTopBorder && drawLeftBorder {
+		intermediaryImage.DrawImage(topLeftCorner, &ebiten.DrawImageOptions{})
+	}
+
+	if drawTopBorder && drawRightBorder {
+		dio := &ebiten.DrawImageOptions{}
+		dio.GeoM.Translate(float64(width/scale+numberOfTrues(drawLeftBorder)*horizontalBorderHeightPx), 0)
+		intermediaryImage.DrawImage(topRightCorner, dio)
+	}
+
+	if drawBottomBorder && drawLeftBorder {
+		dio := &ebiten.DrawImageOptions{}
+		dio.GeoM.Translate(0, float64(height/scale+numberOfTrues(drawTopBorder)*horizontalBorderHeightPx))
+		intermediaryImage.DrawImage(bottomLeftCorner, dio)
+	}
+
+	if drawBottomBorder && drawRightBorder {
+		dio := &ebiten.DrawImageOptions{}
+		dio.GeoM.Translate(float64(width/scale+numberOfTrues(drawLeftBorder)*horizontalBorderHeightPx), float64(height/scale+numberOfTrues(drawTopBorder)*horizontalBorderHeightPx))
+		intermediaryImage.DrawImage(bottomRightCorner, dio)
+	}
+
+	dio := &ebiten.DrawImageOptions{}
+	dio.GeoM.Scale(float64(scale), float64(scale))
+	dio.GeoM.Translate(float64(x-numberOfTrues(drawLeftBorder)*scale*horizontalBorderHeightPx), float64(y-numberOfTrues(drawTopBorder)*scale*horizontalBorderHeightPx))
+	screen.DrawImage(intermediaryImage, dio)
+	intermediaryImage.Dispose()
+}
+
+func numberOfTrues(conditions ...bool) int {
+	number := 0
+	for _, b := range conditions {
+		if b {
+			number++
+		}
+	}
+	return number
+}
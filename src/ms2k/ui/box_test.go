@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+// boundsOf returns the area of the screen covered by the given quads
+func boundsOf(quads []boxQuad) image.Rectangle {
+	bounds := image.Rectangle{}
+	for i, quad := range quads {
+		width, height := quad.piece.size()
+		x0, y0 := quad.geoM.Apply(0, 0)
+		x1, y1 := quad.geoM.Apply(float64(width), float64(height))
+		quadBounds := image.Rect(int(math.Round(x0)), int(math.Round(y0)), int(math.Round(x1)), int(math.Round(y1)))
+		if i == 0 {
+			bounds = quadBounds
+		} else {
+			bounds = bounds.Union(quadBounds)
+		}
+	}
+	return bounds
+}
+
+func TestLayoutBox(t *testing.T) {
+	border := boxScale * boxBorderSize
+	for _, testCase := range []struct {
+		name          string
+		borderOption  BorderOption
+		expectedCount int
+		expected      image.Rectangle
+	}{
+		{"all borders", AllBorders, 8, image.Rect(100-border, 50-border, 400+border, 80+border)},
+		{"bottom right", Bottom | Right, 3, image.Rect(100, 50, 400+border, 80+border)},
+		{"left only", Left, 1, image.Rect(100-border, 50, 100, 80)},
+		{"no border", NoBorder, 0, image.Rectangle{}},
+	} {
+		quads, count := layoutBox(100, 50, 300, 30, testCase.borderOption)
+		if count != testCase.expectedCount {
+			t.Errorf("unexpected number of pieces for [%s]: wanted [%d], got [%d]", testCase.name, testCase.expectedCount, count)
+			continue
+		}
+		if bounds := boundsOf(quads[:count]); bounds != testCase.expected {
+			t.Errorf("unexpected area covered by the borders of [%s]: wanted [%v], got [%v]", testCase.name, testCase.expected, bounds)
+		}
+	}
+}
+
+func TestLayoutBoxStretchesBordersAlongEdges(t *testing.T) {
+	quads, count := layoutBox(0, 0, 200, 60, Left|Top)
+	for _, quad := range quads[:count] {
+		if quad.piece != topBorder && quad.piece != bottomBorder {
+			continue
+		}
+		bounds := boundsOf([]boxQuad{quad})
+		if bounds.Dx() != 200 && bounds.Dy() != 60 {
+			t.Errorf("a border should span a whole edge of the box: got [%v]", bounds)
+		}
+		if min(bounds.Dx(), bounds.Dy()) != boxScale*boxBorderSize {
+			t.Errorf("a border should keep its thickness: got [%v]", bounds)
+		}
+	}
+}
+
+func TestLayoutBoxDoesNotAllocate(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		layoutBox(100, 50, 300, 30, AllBorders)
+	})
+	if allocs != 0 {
+		t.Errorf("laying out a box should not allocate: got [%v] allocations", allocs)
+	}
+}
@@ -19,7 +19,7 @@ type LongTricklingText struct {
 	tt *TricklingText
 }
 
-func NewLongTricklingText(texts []string, timeNow time.Time, frequency time.Duration, assetLibrary *assets.Library) *LongTricklingText {
+func NewLongTricklingText(texts []string, timeNow time.Duration, frequency time.Duration, assetLibrary *assets.Library) *LongTricklingText {
 	return &LongTricklingText{
 		texts:        texts,
 		tt:           NewTricklingText(texts[0], timeNow, frequency, assetLibrary),
@@ -28,7 +28,7 @@ func NewLongTricklingText(texts []string, timeNow time.Time, frequency time.Dura
 	}
 }
 
-func (ltt *LongTricklingText) Update(timeNow time.Time) (addedRune, allShown bool) {
+func (ltt *LongTricklingText) Update(timeNow time.Duration) (addedRune, allShown bool) {
 	addedRune, stepAllShown := ltt.tt.Update(timeNow)
 	if !stepAllShown {
 		return addedRune, false
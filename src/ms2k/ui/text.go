@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/assets"
+)
+
+// TextStyle is the role of a text, which determines its font and size
+type TextStyle uint8
+
+// Enum of all text styles
+const (
+	TitleText TextStyle = iota
+	BodyText
+	HUDText
+	SmallText
+)
+
+type textStyleDefinition struct {
+	fontName string
+	size     float64
+}
+
+var textStyles = map[TextStyle]textStyleDefinition{
+	TitleText: {fontName: "oxanium", size: 32},
+	BodyText:  {fontName: "oxanium", size: 24},
+	HUDText:   {fontName: "oxanium", size: 20},
+	SmallText: {fontName: "oxanium", size: 16},
+}
+
+const largeTextScale = 1.25
+
+var largeText = false
+
+// SetLargeText enables or disables larger text sizes for all text styles
+func SetLargeText(enabled bool) {
+	largeText = enabled
+}
+
+// Alignment tells how a text is placed relatively to the x coordinate it is drawn at
+type Alignment uint8
+
+// Enum of all text alignments
+const (
+	AlignLeft Alignment = iota
+	AlignCenter
+	AlignRight
+)
+
+// TextMetrics holds the vertical metrics of the lines of a text style
+type TextMetrics struct {
+	LineHeight int // height of a line of text
+	Baseline   int // distance between the top of a line and its baseline, so that the text is vertically centered in the line
+}
+
+// FontFace returns the font face used to draw texts of the given style
+func FontFace(assetLibrary *assets.Library, style TextStyle) font.Face {
+	definition := textStyles[style]
+	size := definition.size
+	if largeText {
+		size *= largeTextScale
+	}
+	fontFace, _ := assetLibrary.FontFace(definition.fontName, size)
+	return fontFace
+}
+
+// Metrics returns the line metrics of the given text style
+func Metrics(assetLibrary *assets.Library, style TextStyle) TextMetrics {
+	metrics := FontFace(assetLibrary, style).Metrics()
+	return TextMetrics{
+		LineHeight: metrics.Height.Ceil(),
+		Baseline:   (metrics.Ascent + (metrics.Height-metrics.Ascent-metrics.Descent)/2).Ceil(),
+	}
+}
+
+// MeasureText returns the width of the given text when drawn with the given style
+func MeasureText(assetLibrary *assets.Library, style TextStyle, s string) int {
+	return text.BoundString(FontFace(assetLibrary, style), s).Dx()
+}
+
+// DrawText draws a text whose first line has its top at y.
+// The x coordinate is where the text starts, ends or is centered depending on the alignment;
+// texts spanning several lines are aligned as a whole block.
+func DrawText(screen *ebiten.Image, assetLibrary *assets.Library, style TextStyle, s string, x, y int, alignment Alignment, clr color.Color) {
+	switch alignment {
+	case AlignCenter:
+		x -= MeasureText(assetLibrary, style, s) / 2
+	case AlignRight:
+		x -= MeasureText(assetLibrary, style, s)
+	}
+	text.Draw(screen, s, FontFace(assetLibrary, style), x, y+Metrics(assetLibrary, style).Baseline, clr)
+}
+
+// DrawTextInBox draws a box of the given width centered on x, with a single line text centered in it.
+// The box is as high as a line of the text style, and its top is at y.
+func DrawTextInBox(screen *ebiten.Image, assetLibrary *assets.Library, style TextStyle, s string, x, y, width int, borderOption BorderOption, clr color.Color) {
+	DrawBoxAround(screen, assetLibrary, x-width/2, y, width, Metrics(assetLibrary, style).LineHeight, borderOption)
+	DrawText(screen, assetLibrary, style, s, x, y, AlignCenter, clr)
+}
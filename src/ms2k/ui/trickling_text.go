@@ -17,7 +17,7 @@ type TricklingText struct {
 	trickledLines []string
 	width, height int
 
-	start     time.Time
+	start     time.Duration
 	frequency time.Duration
 
 	numberOfRunesToShow int
@@ -28,7 +28,9 @@ type TricklingText struct {
 	assetLibrary *assets.Library
 }
 
-func NewTricklingText(text string, timeNow time.Time, frequency time.Duration, assetLibrary *assets.Library) *TricklingText {
+// NewTricklingText creates a text that shows one more rune every given frequency.
+// Times are read on a timing.Clock, so that the text stops trickling when the clock is paused.
+func NewTricklingText(text string, timeNow time.Duration, frequency time.Duration, assetLibrary *assets.Library) *TricklingText {
 	return &TricklingText{
 		text:         text,
 		start:        timeNow,
@@ -37,14 +39,14 @@ func NewTricklingText(text string, timeNow time.Time, frequency time.Duration, a
 	}
 }
 
-func (tt *TricklingText) Update(timeNow time.Time) (addedRune, allShown bool) {
+func (tt *TricklingText) Update(timeNow time.Duration) (addedRune, allShown bool) {
 	if inpututil.IsKeyJustPressed(ebiten.KeySpace) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
 		tt.showAll = true
 	}
 	if tt.showAll {
 		tt.numberOfRunesToShow = math.MaxInt
 	} else {
-		tt.numberOfRunesToShow = int(timeNow.Sub(tt.start) / tt.frequency)
+		tt.numberOfRunesToShow = int((timeNow - tt.start) / tt.frequency)
 	}
 
 	if tt.addedRunes {
@@ -2,99 +2,199 @@ package ms2k
 
 import (
 	"fmt"
+	"image/color"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/colorm"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 
 	"github.com/RemiEven/michelSpace2000/src/ms2k/assets"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/events"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/exploration"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/generation"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/palette"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/physics"
 	"github.com/RemiEven/michelSpace2000/src/ms2k/rng"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/sharecode"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/timing"
 	"github.com/RemiEven/michelSpace2000/src/ms2k/ui"
 )
 
 const (
-	cellSize  = 50
-	chunkSize = 32
+	cellSize  = generation.GameCellSize
+	chunkSize = generation.GameChunkSize
+
+	unknownPlanetName = "Unknown object"
+
+	moonScanDistance = 25
+
+	simulationTick = time.Second / 60 // duration simulated by each step of the world, one step per update at normal speed as ebiten runs 60 updates per second
+)
+
+var (
+	unknownBlipColor = color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xc0}
+	moonColor        = color.RGBA{R: 0xd0, G: 0x60, B: 0x50, A: 0xff}
 )
 
 // World contains data such as all the Planets & Ships of the game
 type World struct {
 	Planets           []*Planet
 	WormHoles         []*WormHole
+	Stars             []*Star
+	Nebulae           []*Nebula
 	GeneratedChunks   map[int]map[int]struct{}
+	chunkContents     map[chunkCoordinates]*chunkContent
 	Ships             []*Ship
 	selectedShipIndex int
 
-	rng *rng.RNG
+	rng     *rng.RNG
+	options sharecode.Options
+
+	Exploration *exploration.Map
+
+	camera    *Camera
+	galaxyMap *GalaxyMap
+
+	clock          *timing.Clock    // time of the world, at which it is drawn, paused until the intro is read
+	simulation     *timing.Clock    // time up to which the world is simulated, stepped by whole ticks behind clock
+	interfaceClock *timing.Clock    // time of the game, which keeps running for the cameras and texts outside of the simulation
+	timeline       *timing.Timeline // animations played on the simulation clock
+	lastUpdateTime time.Duration    // on the interface clock
+	effects        *worldEffects
 
-	score int
+	score   int
+	newHome *Planet // the first habitable planet found, which wins the game
 
-	lose *Operation
+	lose *timing.Progress // the doomsday clock
 
-	bottomText          *ui.LongTricklingText
-	displayedPlanetName string
+	bottomText       *ui.LongTricklingText
+	displayedPlanet  *Planet
+	scanNotification *scanNotification
+
+	events       *events.Scheduler
+	announcement *ui.LongTricklingText // non-blocking message about an event, shown in the bottom panel
+	announcedAt  time.Duration
+
+	lastProgressAt time.Duration // simulation time of the last scan or answered beacon, which keeps the doomsday clock from speeding up
 
 	assetLibrary *assets.Library
 }
 
-// NewWorld creates a new world
-func NewWorld(rng *rng.RNG, timeNow time.Time, assetLibrary *assets.Library) *World {
+// NewWorld creates a new world. Its cameras and intro follow the given clock, while its simulation has its own clock.
+func NewWorld(rng *rng.RNG, options sharecode.Options, interfaceClock *timing.Clock, assetLibrary *assets.Library) *World {
+	// ships start docked next to Earth
 	ship1 := &Ship{
-		PlanetScans: map[*Planet]*Operation{},
+		Position:    Position{X: -24, Y: 24},
+		Docked:      true,
+		Stats:       defaultShipStats,
+		PlanetScans: map[*Planet]*timing.Progress{},
+		MoonScans:   map[*Moon]*timing.Progress{},
 	}
 	ship2 := &Ship{
-		PlanetScans: map[*Planet]*Operation{},
+		Position:    Position{X: 24, Y: 24},
+		Docked:      true,
+		Stats:       defaultShipStats,
+		PlanetScans: map[*Planet]*timing.Progress{},
+		MoonScans:   map[*Moon]*timing.Progress{},
 	}
 
 	planets := make([]*Planet, 1)
 	planets[0] = &Planet{
 		Name:   "Earth",
 		Looted: true,
+		Mass:   1,
+		Radius: earthRadius,
 	}
 
-	return &World{
+	w := &World{
 		Planets:         planets,
 		Ships:           []*Ship{ship1, ship2},
 		GeneratedChunks: map[int]map[int]struct{}{},
-
-		lose: &Operation{
-			lastUpdate: timeNow,
-			speed:      4. / 5.,
-			paused:     true,
-		},
-		rng:          rng,
-		assetLibrary: assetLibrary,
-		bottomText:   ui.NewLongTricklingText(intro, timeNow, 40*time.Millisecond, assetLibrary),
+		chunkContents:   map[chunkCoordinates]*chunkContent{},
+		Exploration:     exploration.NewMap(cellSize),
+		effects:         newWorldEffects(rng.Stream("particles").Int63()),
+		events:          events.NewScheduler(rng.Stream("events")),
+
+		rng:            rng,
+		options:        options,
+		clock:          timing.NewClock(),
+		simulation:     timing.NewClock(),
+		interfaceClock: interfaceClock,
+		lastUpdateTime: interfaceClock.Now(),
+		camera:         NewCamera(Position{}, interfaceClock.Now()),
+		galaxyMap:      NewGalaxyMap(interfaceClock.Now(), assetLibrary),
+		assetLibrary:   assetLibrary,
+		bottomText:     ui.NewLongTricklingText(intro, interfaceClock.Now(), 40*time.Millisecond, assetLibrary),
 	}
+	w.clock.SetScale(0)
+	w.timeline = timing.NewTimeline(w.simulation)
+	w.lose = timing.NewProgress(w.simulation, doomsdayClockSpeeds[options.Difficulty])
+	earthContent := w.contentOfChunkContaining(planets[0].Position)
+	earthContent.planets = append(earthContent.planets, planets[0])
+	return w
 }
 
 // Update updates the world
-func (w *World) Update(timeNow time.Time, settings *Settings) int8 {
+func (w *World) Update(settings *Settings) int8 {
+	timeNow := w.interfaceClock.Now()
+	w.clock.Advance(timeNow - w.lastUpdateTime)
+	w.lastUpdateTime = timeNow
+
 	if w.bottomText != nil {
 		_, allShown := w.bottomText.Update(timeNow)
 		if allShown && (inpututil.IsKeyJustPressed(ebiten.KeySpace) || inpututil.IsKeyJustPressed(ebiten.KeyEnter)) {
 			w.bottomText = nil
-			w.lose.Resume(timeNow)
+			w.clock.SetScale(1)
 		}
 		return stateInGame
 	}
 
 	if inpututil.IsKeyJustPressed(keyMapping.PreviousShip) {
 		w.selectPreviousShip()
+		w.camera.Follow()
 	}
 	if inpututil.IsKeyJustPressed(keyMapping.NextShip) {
 		w.selectNextShip()
+		w.camera.Follow()
 	}
 
-	if inpututil.IsKeyJustPressed(keyMapping.ZoomIn) {
-		zoomFactor = zoomFactor * 2
+	if inpututil.IsKeyJustPressed(keyMapping.ToggleMap) {
+		w.galaxyMap.Toggle(w.camera.Position())
 	}
-	if inpututil.IsKeyJustPressed(keyMapping.ZoomOut) {
-		zoomFactor = zoomFactor / 2
+
+	if w.galaxyMap.IsOpen() {
+		if inpututil.IsKeyJustPressed(keyMapping.ZoomIn) {
+			w.galaxyMap.ZoomIn()
+		}
+		if inpututil.IsKeyJustPressed(keyMapping.ZoomOut) {
+			w.galaxyMap.ZoomOut()
+		}
+	} else {
+		if inpututil.IsKeyJustPressed(keyMapping.ZoomIn) {
+			w.camera.ZoomIn()
+		}
+		if inpututil.IsKeyJustPressed(keyMapping.ZoomOut) {
+			w.camera.ZoomOut()
+		}
+		if inpututil.IsKeyJustPressed(keyMapping.FreeLook) {
+			if w.camera.Mode() == CameraFreeLook {
+				w.camera.Follow()
+			} else {
+				w.camera.FreeLook()
+			}
+		}
+		if inpututil.IsKeyJustPressed(keyMapping.FocusEarth) {
+			if w.camera.Mode() == CameraFocus {
+				w.camera.Follow()
+			} else {
+				w.camera.FocusOn(w.Planets[0].Position)
+			}
+		}
 	}
 
 	var (
@@ -112,40 +212,57 @@ func (w *World) Update(timeNow time.Time, settings *Settings) int8 {
 	)
 
 	selectedShip := w.getSelectedShip()
-	speed := 3.0
-	if goesNorth {
-		selectedShip.Position.Y -= speed
-	}
-	if goesSouth {
-		selectedShip.Position.Y += speed
+	thrust := physics.Vector{}
+	switch {
+	case w.galaxyMap.IsOpen():
+		w.galaxyMap.Pan(boolToAxis(goesWest, goesEast), boolToAxis(goesNorth, goesSouth))
+	case w.camera.Mode() == CameraFreeLook:
+		w.camera.Pan(boolToAxis(goesWest, goesEast), boolToAxis(goesNorth, goesSouth))
+	default:
+		if w.camera.Mode() == CameraFocus && (goesNorth || goesSouth || goesWest || goesEast) {
+			w.camera.Follow()
+		}
+		thrust = physics.Vector{X: boolToAxis(goesWest, goesEast), Y: boolToAxis(goesNorth, goesSouth)}
 	}
-	if goesWest {
-		selectedShip.Position.X -= speed
+
+	// the world is simulated by whole ticks, so that it does not depend on the time scale; it is drawn in between
+	for w.clock.Since(w.simulation.Now()) >= simulationTick {
+		if state := w.step(thrust); state != stateInGame {
+			return state
+		}
 	}
-	if goesEast {
-		selectedShip.Position.X += speed
+
+	w.camera.Update(timeNow, selectedShip.Position)
+	w.galaxyMap.Update(timeNow, selectedShip.Position, settings.keyboardLayout)
+	w.ensureChunksAroundAreGenerated(w.camera.Position())
+
+	return stateInGame
+}
+
+// step simulates the world during one tick, the selected ship being pushed by the given thrust
+func (w *World) step(thrust physics.Vector) int8 {
+	w.simulation.Advance(simulationTick)
+	w.timeline.Update()
+
+	selectedShip := w.getSelectedShip()
+	for _, ship := range w.Ships {
+		if ship == selectedShip {
+			w.stepShip(ship, thrust, simulationTick)
+		} else {
+			w.stepShip(ship, physics.Vector{}, simulationTick)
+		}
 	}
+	w.ensureChunksAroundAreGenerated(selectedShip.Position)
 
-	switch {
-	case goesNorth && goesWest:
-		selectedShip.Direction = Northwest
-	case goesWest && goesSouth:
-		selectedShip.Direction = Southwest
-	case goesSouth && goesEast:
-		selectedShip.Direction = Southeast
-	case goesEast && goesNorth:
-		selectedShip.Direction = Northeast
-	case goesNorth:
-		selectedShip.Direction = North
-	case goesWest:
-		selectedShip.Direction = West
-	case goesSouth:
-		selectedShip.Direction = South
-	case goesEast:
-		selectedShip.Direction = East
+	for _, planet := range w.Planets {
+		planet.updateMoons(w.simulation.Now())
 	}
 
-	w.ensureChunksAroundAreGenerated(selectedShip.Position)
+	w.updateEvents()
+
+	for _, ship := range w.Ships {
+		w.Exploration.Explore(ship.Position.X, ship.Position.Y, w.sensorRange(ship))
+	}
 
 	for _, ship := range w.Ships {
 		var closestPlanet *Planet
@@ -158,40 +275,69 @@ func (w *World) Update(timeNow time.Time, settings *Settings) int8 {
 			}
 			if !planet.Looted && distanceToShip < 50 {
 				if _, ok := ship.PlanetScans[planet]; !ok {
-					ship.PlanetScans[planet] = &Operation{
-						lastUpdate: timeNow,
-						speed:      50,
-					}
+					ship.PlanetScans[planet] = timing.NewProgress(w.simulation, 50)
+				}
+			}
+			for _, moon := range planet.Moons {
+				if _, ok := ship.MoonScans[moon]; !ok && !moon.Looted && ship.Position.DistanceTo(&moon.Position) < moonScanDistance {
+					ship.MoonScans[moon] = timing.NewProgress(w.simulation, 100)
 				}
 			}
 		}
 
 		if ship == selectedShip {
 			if distanceToClosestPlanet < 50 {
-				w.displayedPlanetName = closestPlanet.Name
+				w.displayedPlanet = closestPlanet
 			} else {
-				w.displayedPlanetName = ""
+				w.displayedPlanet = nil
 			}
 		}
 
 		for planet, scan := range ship.PlanetScans {
 			if !planet.Looted && ship.Position.DistanceTo(&planet.Position) < 50 {
-				scan.Update(timeNow)
+				w.updateScan(scan)
 				if scan.IsCompleted() {
 					delete(ship.PlanetScans, planet)
 					w.score++
 					planet.Looted = true
+					if planet.Attributes.IsHabitable() && w.newHome == nil {
+						w.newHome = planet
+					}
+					if habitability := planet.Attributes.Habitability(); habitability >= promisingHabitability {
+						w.shiftDoomsdayClock(-promisingPlanetBonus*habitability, "promising planet scanned")
+					}
+					w.recordProgress()
+					w.effects.burst(planet.Position, planetMapColor(planet))
+					w.notifyScanCompleted(planet)
 				}
 			} else {
 				delete(ship.PlanetScans, planet)
 			}
 		}
+
+		for moon, scan := range ship.MoonScans {
+			if !moon.Looted && ship.Position.DistanceTo(&moon.Position) < moonScanDistance {
+				w.updateScan(scan)
+				if scan.IsCompleted() {
+					delete(ship.MoonScans, moon)
+					moon.Looted = true
+					// scanning a moon is a bonus that pushes the doomsday clock back a little
+					w.shiftDoomsdayClock(-moonScanBonus, "moon scanned")
+					w.recordProgress()
+					w.effects.burst(moon.Position, moonColor)
+				}
+			} else {
+				delete(ship.MoonScans, moon)
+			}
+		}
 	}
 
-	if w.score >= 10 {
+	w.effects.update(w, w.simulation.Now())
+
+	if w.newHome != nil {
 		return stateWon
 	}
-	w.lose.Update(timeNow)
+	w.updateDoomsdayClock()
 	if w.lose.IsCompleted() {
 		return stateLost
 	}
@@ -199,6 +345,63 @@ func (w *World) Update(timeNow time.Time, settings *Settings) int8 {
 	return stateInGame
 }
 
+func (w *World) isExplored(p Position) bool {
+	return !w.options.FogOfWar || w.Exploration.IsExplored(p.X, p.Y)
+}
+
+// explorationCoverage returns the share of the generated part of the world that has been explored
+func (w *World) explorationCoverage() float64 {
+	numberOfChunks := 0
+	for _, column := range w.GeneratedChunks {
+		numberOfChunks += len(column)
+	}
+	return w.Exploration.Coverage(numberOfChunks * chunkSize * chunkSize)
+}
+
+// drawUnknownBlip draws an unexplored celestial body as a blip that does not tell what it is
+func (w *World) drawUnknownBlip(screen *ebiten.Image, p Position) {
+	x, y := w.camera.WorldToScreen(p)
+	radius := float32(math.Max(2, 6*w.camera.Zoom()))
+	vector.DrawFilledCircle(screen, float32(x), float32(y), radius, unknownBlipColor, true)
+}
+
+// bestHabitability returns the highest habitability among scanned planets
+func (w *World) bestHabitability() float64 {
+	best := 0.0
+	for _, planet := range w.Planets[1:] {
+		if planet.Looted {
+			best = max(best, planet.Attributes.Habitability())
+		}
+	}
+	return best
+}
+
+// StatisticsLines returns a summary of how the game went
+func (w *World) StatisticsLines() []string {
+	lines := []string{}
+	if w.newHome != nil {
+		lines = append(lines, "New home: "+w.newHome.DisplayName())
+	}
+	lines = append(lines,
+		"Worlds scanned: "+strconv.Itoa(w.score),
+		"Best habitability found: "+formatHabitability(w.bestHabitability()),
+		fmt.Sprintf("Space explored: %v sectors (%.0f%% of charted space)", w.Exploration.ExploredCells(), 100*w.explorationCoverage()),
+	)
+	if code, err := w.ShareCode(); err == nil {
+		lines = append(lines, "Share code: "+code)
+	}
+	return lines
+}
+
+// ShareCode returns the code that lets other players play the same game
+func (w *World) ShareCode() (string, error) {
+	code, err := sharecode.Encode(w.rng.Seed(), w.options)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode share code: %w", err)
+	}
+	return code, nil
+}
+
 func (w *World) getSelectedShip() *Ship {
 	return w.Ships[w.selectedShipIndex]
 }
@@ -213,35 +416,58 @@ func (w *World) selectPreviousShip() {
 
 // Draw draws the world
 func (w *World) Draw(screen *ebiten.Image) {
-	fontFace, _ := w.assetLibrary.FontFaces.Load("oxanium")
-	fontFaceHeight := fontFace.Metrics().Height.Ceil()
-	fontShift := (fontFace.Metrics().Ascent + (fontFace.Metrics().Height-fontFace.Metrics().Ascent-fontFace.Metrics().Descent)/2).Ceil()
-
-	viewPortCenter := w.getSelectedShip().Position
-
-	drawSpaceBackground(screen, w.assetLibrary, viewPortCenter)
+	if w.galaxyMap.IsOpen() {
+		w.galaxyMap.Draw(screen, w)
+		return
+	}
 
 	screenBounds := screen.Bounds()
 	screenWidth, screenHeight := float64(screenBounds.Dx()), float64(screenBounds.Dy())
 
-	minXToDisplay := viewPortCenter.X - (screenWidth/2/zoomFactor + viewportBorderMargin)
-	maxXToDisplay := viewPortCenter.X + (screenWidth/2/zoomFactor + viewportBorderMargin)
-	minYToDisplay := viewPortCenter.Y - (screenHeight/2/zoomFactor + viewportBorderMargin)
-	maxYToDisplay := viewPortCenter.Y + (screenHeight/2/zoomFactor + viewportBorderMargin)
+	w.camera.SetViewport(screenBounds.Dx(), screenBounds.Dy())
+	zoom := w.camera.Zoom()
+	// bodies moving on their own are drawn at the time of the world clock, which may be ahead of the last tick of the simulation
+	simulationTime := w.clock.Now()
+
+	drawSpaceBackground(screen, w.assetLibrary, w.camera.Position(), zoom)
+
+	for _, nebula := range w.Nebulae {
+		if w.camera.IsVisible(nebula.Position, nebula.Radius) {
+			x, y := w.camera.WorldToScreen(nebula.Position)
+			vector.DrawFilledCircle(screen, float32(x), float32(y), float32(nebula.Radius*zoom), palette.Nebula(nebula.Kind), true)
+		}
+	}
 
 	{
 		wormHoleImage, _ := w.assetLibrary.Images.Load("wormHole")
 		imageWidth, imageHeight := wormHoleImage.Bounds().Dx(), wormHoleImage.Bounds().Dy()
 		for _, wormHole := range w.WormHoles {
-			if isInBox(wormHole.Position.X, wormHole.Position.Y, minXToDisplay, maxXToDisplay, minYToDisplay, maxYToDisplay) {
-				dio := &ebiten.DrawImageOptions{}
-				scale := 2 * zoomFactor
-				dio.GeoM.Scale(scale, scale)
-				dio.GeoM.Translate(-float64(imageWidth)/2.0*scale, -float64(imageHeight)/2.0*scale)
+			if !w.camera.IsVisible(wormHole.Position, viewportBorderMargin) {
+				continue
+			}
+			if !w.isExplored(wormHole.Position) {
+				w.drawUnknownBlip(screen, wormHole.Position)
+				continue
+			}
+			dio := &ebiten.DrawImageOptions{}
+			scale := 2 * zoom
+			dio.GeoM.Translate(-float64(imageWidth)/2.0, -float64(imageHeight)/2.0)
+			dio.GeoM.Scale(scale, scale)
+			dio.GeoM.Rotate(wormHole.RotationAt(simulationTime))
+
+			w.camera.TranslateToScreen(&dio.GeoM, wormHole.Position)
 
-				translateToDrawPosition(&screenBounds, wormHole.Position, viewPortCenter, &dio.GeoM, zoomFactor)
+			screen.DrawImage(wormHoleImage, dio)
+		}
+	}
 
-				screen.DrawImage(wormHoleImage, dio)
+	for _, star := range w.Stars {
+		if w.camera.IsVisible(star.Position, viewportBorderMargin) {
+			x, y := w.camera.WorldToScreen(star.Position)
+			r, g, b, _ := palette.Star(star.Temperature).RGBA()
+			for _, halo := range []struct{ scale, alpha float64 }{{2.2, 0.12}, {1.5, 0.3}, {1, 1}} {
+				haloColor := color.RGBA64{R: uint16(float64(r) * halo.alpha), G: uint16(float64(g) * halo.alpha), B: uint16(float64(b) * halo.alpha), A: uint16(0xffff * halo.alpha)}
+				vector.DrawFilledCircle(screen, float32(x), float32(y), float32(star.Radius*halo.scale*zoom), haloColor, true)
 			}
 		}
 	}
@@ -255,42 +481,52 @@ func (w *World) Draw(screen *ebiten.Image) {
 			if planet == w.Planets[0] {
 				continue
 			}
-			if isInBox(planet.Position.X, planet.Position.Y, minXToDisplay, maxXToDisplay, minYToDisplay, maxYToDisplay) {
-				dio := &colorm.DrawImageOptions{}
-				scale := 0.25 * zoomFactor
-				dio.GeoM.Scale(scale, scale)
-				dio.GeoM.Translate(-float64(imageWidth)/2.0*scale, -float64(imageHeight)/2.0*scale)
+			if !w.camera.IsVisible(planet.Position, viewportBorderMargin) {
+				continue
+			}
+			if !w.isExplored(planet.Position) {
+				w.drawUnknownBlip(screen, planet.Position)
+				continue
+			}
+			dio := &colorm.DrawImageOptions{}
+			scale := 0.25 * planet.Radius / planetRadius * zoom
+			dio.GeoM.Translate(-float64(imageWidth)/2.0, -float64(imageHeight)/2.0)
+			dio.GeoM.Scale(scale, scale)
+			dio.GeoM.Rotate(planet.RotationAt(simulationTime))
 
-				translateToDrawPosition(&screenBounds, planet.Position, viewPortCenter, &dio.GeoM, zoomFactor)
+			w.camera.TranslateToScreen(&dio.GeoM, planet.Position)
 
-				cm := colorm.ColorM{}
-				cm.ChangeHSV(planet.Hue, 1, 1)
+			cm := colorm.ColorM{}
+			hueShift, saturation, value := planetAppearance(planet)
+			cm.ChangeHSV(hueShift, saturation, value)
 
-				colorm.DrawImage(screen, planetImage, cm, dio)
-				moonImageWidth, moonImageHeight := moonImage.Bounds().Dx(), moonImage.Bounds().Dy()
-				for _, moon := range planet.Moons {
-					dio := &ebiten.DrawImageOptions{}
-					scale := zoomFactor
-					dio.GeoM.Scale(scale, scale)
-					dio.GeoM.Translate(-float64(moonImageWidth)/2.0*scale, -float64(moonImageHeight)/2.0*scale)
-					translateToDrawPosition(&screenBounds, moon.Position, viewPortCenter, &dio.GeoM, zoomFactor)
-					screen.DrawImage(moonImage, dio)
+			colorm.DrawImage(screen, planetImage, cm, dio)
+			moonImageWidth, moonImageHeight := moonImage.Bounds().Dx(), moonImage.Bounds().Dy()
+			for _, moon := range planet.Moons {
+				dio := &ebiten.DrawImageOptions{}
+				scale := zoom
+				dio.GeoM.Scale(scale, scale)
+				dio.GeoM.Translate(-float64(moonImageWidth)/2.0*scale, -float64(moonImageHeight)/2.0*scale)
+				w.camera.TranslateToScreen(&dio.GeoM, moon.PositionAt(planet.Position, simulationTime))
+				if moon.Looted {
+					dio.ColorScale.Scale(0.6, 0.6, 0.6, 1)
 				}
-				if planet.Looted {
-					satelliteImageWidth, satelliteImageHeight := satelliteImage.Bounds().Dx(), satelliteImage.Bounds().Dy()
-					dio := &ebiten.DrawImageOptions{}
-					scale := zoomFactor
-					dio.GeoM.Scale(scale, scale)
-					dio.GeoM.Translate(-float64(satelliteImageWidth)/2.0*scale, -float64(satelliteImageHeight)/2.0*scale)
-
-					distance := 38
-					position := Position{
-						X: planet.Position.X + math.Sqrt2*float64(distance/2),
-						Y: planet.Position.Y - math.Sqrt2*float64(distance/2),
-					}
-					translateToDrawPosition(&screenBounds, position, viewPortCenter, &dio.GeoM, zoomFactor)
-					screen.DrawImage(satelliteImage, dio)
+				screen.DrawImage(moonImage, dio)
+			}
+			if planet.Looted {
+				satelliteImageWidth, satelliteImageHeight := satelliteImage.Bounds().Dx(), satelliteImage.Bounds().Dy()
+				dio := &ebiten.DrawImageOptions{}
+				scale := zoom
+				dio.GeoM.Scale(scale, scale)
+				dio.GeoM.Translate(-float64(satelliteImageWidth)/2.0*scale, -float64(satelliteImageHeight)/2.0*scale)
+
+				distance := 38
+				position := Position{
+					X: planet.Position.X + math.Sqrt2*float64(distance/2),
+					Y: planet.Position.Y - math.Sqrt2*float64(distance/2),
 				}
+				w.camera.TranslateToScreen(&dio.GeoM, position)
+				screen.DrawImage(satelliteImage, dio)
 			}
 		}
 	}
@@ -298,62 +534,87 @@ func (w *World) Draw(screen *ebiten.Image) {
 	{
 		earthImage, _ := w.assetLibrary.Images.Load("earth")
 		imageWidth, imageHeight := earthImage.Bounds().Dx(), earthImage.Bounds().Dy()
-		if isInBox(0, 0, minXToDisplay, maxXToDisplay, minYToDisplay, maxYToDisplay) {
+		if w.camera.IsVisible(Position{}, viewportBorderMargin) {
 			dio := &ebiten.DrawImageOptions{}
-			scale := 2.0 * zoomFactor
+			scale := 2.0 * zoom
 			dio.GeoM.Scale(scale, scale)
 			dio.GeoM.Translate(-float64(imageWidth)/2.0*scale, -float64(imageHeight)/2.0*scale)
 
-			translateToDrawPosition(&screenBounds, Position{}, viewPortCenter, &dio.GeoM, zoomFactor)
+			w.camera.TranslateToScreen(&dio.GeoM, Position{})
 
 			screen.DrawImage(earthImage, dio)
 		}
 	}
 
+	w.effects.draw(screen, w.camera)
+
 	{
 		shipImage, _ := w.assetLibrary.Images.Load("ship")
 		imageWidth, imageHeight := shipImage.Bounds().Dx(), shipImage.Bounds().Dy()
 		for _, ship := range w.Ships {
-			if isInBox(ship.Position.X, ship.Position.Y, minXToDisplay, maxXToDisplay, minYToDisplay, maxYToDisplay) {
+			if w.camera.IsVisible(ship.Position, viewportBorderMargin) {
 				dio := &ebiten.DrawImageOptions{}
-				scale := 1.0 * zoomFactor
+				scale := 1.0 * zoom
 				dio.GeoM.Scale(scale, scale)
 				dio.GeoM.Translate(-float64(imageWidth)/2.0*scale, -float64(imageHeight)/2.0*scale)
-				dio.GeoM.Rotate(-2.0 * math.Pi / 8.0 * float64(ship.Direction))
+				dio.GeoM.Rotate(ship.Heading)
 
-				translateToDrawPosition(&screenBounds, ship.Position, viewPortCenter, &dio.GeoM, zoomFactor)
+				w.camera.TranslateToScreen(&dio.GeoM, ship.Position)
 
 				screen.DrawImage(shipImage, dio)
 			}
 		}
 	}
 
-	ui.DrawBoxAround(screen, w.assetLibrary, 0, 0, 250, 120, ui.Bottom|ui.Right)
-	text.Draw(screen, strconv.Itoa(w.score)+"/"+strconv.Itoa(10)+" worlds scanned", fontFace, 4, 26, ui.TextColor)
-	text.Draw(screen, w.getSelectedShip().Position.String(), fontFace, 4, 54, ui.TextColor)
-	text.Draw(screen, loseOperationToDoomsdayClockTime(w.lose), fontFace, 4, 110, ui.TextColor)
+	w.drawEvents(screen, simulationTime)
+	w.drawScanRings(screen)
+	w.drawOffscreenIndicators(screen)
+
+	{
+		hudLineHeight := ui.Metrics(w.assetLibrary, ui.HUDText).LineHeight
+		hudLines := []string{
+			strconv.Itoa(w.score) + " worlds scanned",
+			"Best habitability: " + formatHabitability(w.bestHabitability()),
+			w.getSelectedShip().Position.String(),
+			doomsdayClockTime(w.lose),
+		}
+		hudLines = append(hudLines, w.doomsdayClockHUDLines()...)
+		hudLines = append(hudLines, w.eventHUDLines()...)
+		hudWidth, numberOfHUDLines := 0, 0
+		for _, line := range hudLines {
+			hudWidth = max(hudWidth, ui.MeasureText(w.assetLibrary, ui.HUDText, line))
+			numberOfHUDLines += strings.Count(line, "\n") + 1
+		}
+		ui.DrawBoxAround(screen, w.assetLibrary, 0, 0, hudWidth+2*4, hudLineHeight*numberOfHUDLines, ui.Bottom|ui.Right)
+		y := 0
+		for _, line := range hudLines {
+			ui.DrawText(screen, w.assetLibrary, ui.HUDText, line, 4, y, ui.AlignLeft, ui.TextColor)
+			y += hudLineHeight * (strings.Count(line, "\n") + 1)
+		}
+	}
+
+	w.drawSolarFlare(screen, simulationTime)
+	w.drawScanNotification(screen)
+	w.galaxyMap.Draw(screen, w)
 
 	switch {
 	case w.bottomText != nil:
 		w.bottomText.Draw(screen, 40, int(screenHeight)-(128+2*6+2*6), int(screenWidth)-2*40, 128)
-	case w.displayedPlanetName != "":
-		largestPossibleBoundString := text.BoundString(fontFace, "Kepler 99999 jh")
-		ui.DrawBoxAround(screen, w.assetLibrary, (int(screenWidth)-largestPossibleBoundString.Dx())/2, int(screenHeight)-fontFaceHeight, largestPossibleBoundString.Dx(), fontFaceHeight, ui.Left|ui.Top|ui.Right)
-		boundString := text.BoundString(fontFace, w.displayedPlanetName)
-		text.Draw(screen, w.displayedPlanetName, fontFace, (int(screenWidth)-boundString.Dx())/2, int(screenHeight)-fontFaceHeight+fontShift, ui.TextColor)
+	case w.announcement != nil:
+		w.announcement.Draw(screen, 40, int(screenHeight)-(128+2*6+2*6), int(screenWidth)-2*40, 128)
+	case w.displayedPlanet != nil:
+		w.drawPlanetInfoPanel(screen, w.displayedPlanet)
 	}
 }
 
-func loseOperationToDoomsdayClockTime(operation *Operation) string {
-	numberOfSeconds := 5 * 60
-	secondsPerPercent := numberOfSeconds / 100
-
-	minutes := 55 + int(float64(secondsPerPercent)*operation.completedPercentage)/60
-	seconds := int(float64(secondsPerPercent)*operation.completedPercentage) % 60
-
-	if minutes == 60 {
-		return "Midnight"
+// boolToAxis returns -1, 1 or 0 depending on which of the opposite directions is taken
+func boolToAxis(negative, positive bool) float64 {
+	switch {
+	case negative && !positive:
+		return -1
+	case positive && !negative:
+		return 1
+	default:
+		return 0
 	}
-
-	return fmt.Sprintf("23:%02d:%02d", minutes, seconds)
 }
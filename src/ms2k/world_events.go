@@ -0,0 +1,151 @@
+package ms2k
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/RemiEven/michelSpace2000/src/ms2k/events"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/timing"
+	"github.com/RemiEven/michelSpace2000/src/ms2k/ui"
+)
+
+const (
+	asteroidFieldSlowdown = 0.4              // factor applied to the acceleration and max speed of ships in asteroid fields
+	announcementDuration  = 12 * time.Second // simulation time an announcement stays once fully shown
+)
+
+var (
+	asteroidColor    = color.RGBA{R: 0x8a, G: 0x7d, B: 0x70, A: 0xff}
+	beaconColor      = color.RGBA{R: 0xff, G: 0xd0, B: 0x40, A: 0xff}
+	solarFlareColor  = color.RGBA{R: 0x30, G: 0x14, B: 0x00, A: 0x30}
+	asteroidBoundary = color.RGBA{R: 0x22, G: 0x1f, B: 0x1c, A: 0x40}
+)
+
+// updateEvents starts the events that are due, applies their effects and hides the announcement once it has been read
+func (w *World) updateEvents() {
+	selectedShip := w.getSelectedShip()
+	if event := w.events.Update(w.simulation.Now(), selectedShip.Position.X, selectedShip.Position.Y); event != nil {
+		if event.Kind == events.NewsBulletin {
+			w.shiftDoomsdayClock(event.ClockShift, "news from Earth")
+		}
+		w.announce(event.Message)
+	}
+
+	answeredBeacons := []*events.Event{}
+	for _, event := range w.events.Active() {
+		if event.Kind != events.DistressBeacon {
+			continue
+		}
+		for _, ship := range w.Ships {
+			if event.Contains(ship.Position.X, ship.Position.Y) {
+				answeredBeacons = append(answeredBeacons, event)
+				break
+			}
+		}
+	}
+	for _, beacon := range answeredBeacons {
+		w.events.End(beacon)
+		w.shiftDoomsdayClock(-beacon.ClockShift, "distress beacon answered")
+		w.recordProgress()
+		w.effects.burst(Position{X: beacon.X, Y: beacon.Y}, beaconColor)
+		w.announce("We answered the distress beacon. The rescued crew shared their star charts, which will save us precious time.")
+	}
+
+	if w.announcement != nil {
+		_, allShown := w.announcement.Update(w.simulation.Now())
+		if allShown && (inpututil.IsKeyJustPressed(ebiten.KeyEnter) || w.simulation.Now()-w.announcedAt > announcementDuration) {
+			w.announcement = nil
+		}
+	}
+}
+
+// announce shows a message in the bottom panel, without pausing the game
+func (w *World) announce(message string) {
+	w.announcement = ui.NewLongTricklingText([]string{message}, w.simulation.Now(), 20*time.Millisecond, w.assetLibrary)
+	w.announcedAt = w.simulation.Now()
+}
+
+// updateScan makes a scan progress, unless a solar flare blinds the sensors
+func (w *World) updateScan(scan *timing.Progress) {
+	if w.events.IsActive(events.SolarFlare) {
+		scan.Hold()
+	} else {
+		scan.Update()
+	}
+}
+
+// eventHUDLines returns a line for each ongoing event that affects the player
+func (w *World) eventHUDLines() []string {
+	lines := []string{}
+	for _, event := range w.events.Active() {
+		remaining := strconv.Itoa(int((event.Start + event.Duration - w.simulation.Now()).Seconds()))
+		switch event.Kind {
+		case events.SolarFlare:
+			lines = append(lines, "Solar flare, scans paused: "+remaining+"s")
+		case events.DistressBeacon:
+			lines = append(lines, "Distress beacon: "+remaining+"s")
+		}
+	}
+	return lines
+}
+
+// isInAsteroidField returns whether the given position is in an ongoing asteroid field
+func (w *World) isInAsteroidField(p Position) bool {
+	for _, event := range w.events.Active() {
+		if event.Kind == events.AsteroidField && event.Contains(p.X, p.Y) {
+			return true
+		}
+	}
+	return false
+}
+
+// drawEvents draws asteroid fields and distress beacons in the world
+func (w *World) drawEvents(screen *ebiten.Image, simulationTime time.Duration) {
+	zoom := w.camera.Zoom()
+	for _, event := range w.events.Active() {
+		center := Position{X: event.X, Y: event.Y}
+		switch event.Kind {
+		case events.AsteroidField:
+			if !w.camera.IsVisible(center, event.Radius) {
+				continue
+			}
+			x, y := w.camera.WorldToScreen(center)
+			vector.StrokeCircle(screen, float32(x), float32(y), float32(event.Radius*zoom), 1, asteroidBoundary, true)
+			for i := range event.Asteroids {
+				asteroid := &event.Asteroids[i]
+				asteroidX, asteroidY := asteroid.PositionAt(event, simulationTime)
+				x, y := w.camera.WorldToScreen(Position{X: asteroidX, Y: asteroidY})
+				vector.DrawFilledCircle(screen, float32(x), float32(y), float32(asteroid.Size*zoom), asteroidColor, true)
+			}
+		case events.DistressBeacon:
+			if !w.camera.IsVisible(center, event.Radius) {
+				continue
+			}
+			x, y := w.camera.WorldToScreen(center)
+			pulse := math.Mod(simulationTime.Seconds(), 1.5) / 1.5
+			vector.DrawFilledCircle(screen, float32(x), float32(y), float32(5*zoom), beaconColor, true)
+			strokeArc(screen, x, y, event.Radius*zoom*pulse, 0, 2*math.Pi, 2, beaconColor)
+		}
+	}
+}
+
+// drawSolarFlare tints the screen while a solar flare is ongoing
+func (w *World) drawSolarFlare(screen *ebiten.Image, simulationTime time.Duration) {
+	if !w.events.IsActive(events.SolarFlare) {
+		return
+	}
+	intensity := 0.7 + 0.3*math.Sin(4*simulationTime.Seconds())
+	clr := color.RGBA{
+		R: uint8(float64(solarFlareColor.R) * intensity),
+		G: uint8(float64(solarFlareColor.G) * intensity),
+		B: uint8(float64(solarFlareColor.B) * intensity),
+		A: uint8(float64(solarFlareColor.A) * intensity),
+	}
+	vector.DrawFilledRect(screen, 0, 0, float32(screen.Bounds().Dx()), float32(screen.Bounds().Dy()), clr, false)
+}
@@ -2,9 +2,26 @@
 
 assets_source_folder='./src/ms2k/assets/files/'
 assets_dest_folder='./web/assets/'
+manifest_file='manifest.json'
 
 IFS=$'\n'
 for filename in $(find -type f -path ${assets_source_folder}'**/*' | grep -v '.json'); do
     mkdir -p ${assets_dest_folder}$(dirname ${filename#$assets_source_folder})
     mv ${filename} ${assets_dest_folder}${filename#$assets_source_folder}
 done
+
+# the manifest lists the content hash of every asset so that the game can cache them in the browser
+cd ${assets_dest_folder}
+hashes=$(find . -type f ! -name ${manifest_file} -printf '%P\n' | sort | while read -r filename; do
+    echo "$(sha256sum "${filename}" | cut -d ' ' -f 1) ${filename}"
+done)
+version=$(echo "${hashes}" | sha256sum | cut -c 1-16)
+{
+    echo '{'
+    echo "    \"version\": \"${version}\","
+    echo '    "files": {'
+    echo "${hashes}" | awk 'NR > 1 { print "," } { hash = $1; sub(/^[^ ]* /, ""); printf "        \"%s\": \"%s\"", $0, hash }'
+    echo ''
+    echo '    }'
+    echo '}'
+} > ${manifest_file}